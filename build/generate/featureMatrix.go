@@ -41,6 +41,12 @@ func generateFeatureMatrix() error {
 			{"R53_ALIAS", "Provider supports Route 53 limited ALIAS"},
 			{"AZURE_ALIAS", "Provider supports Azure DNS limited ALIAS"},
 			{"DS", "Provider supports adding DS records"},
+			{"HINFO", "Provider can manage HINFO records"},
+			{"RP", "Provider can manage RP records"},
+			{"DHCID", "Provider can manage DHCID records"},
+			{"LOC", "Provider can manage LOC records"},
+			{"SVCB", "Provider can manage SVCB records"},
+			{"HTTPS", "Provider can manage HTTPS records"},
 
 			{"dual host", "This provider is recommended for use in 'dual hosting' scenarios. Usually this means the provider allows full control over the apex NS records"},
 			{"create-domains", "This means the provider can automatically create domains that do not currently exist on your account. The 'dnscontrol create-domains' command will initialize any missing domains"},
@@ -89,6 +95,12 @@ func generateFeatureMatrix() error {
 		setCap("TXTMulti", providers.CanUseTXTMulti)
 		setCap("get-zones", providers.CanGetZones)
 		setCap("DS", providers.CanUseDS)
+		setCap("HINFO", providers.CanUseHINFO)
+		setCap("RP", providers.CanUseRP)
+		setCap("DHCID", providers.CanUseDHCID)
+		setCap("LOC", providers.CanUseLOC)
+		setCap("SVCB", providers.CanUseSVCB)
+		setCap("HTTPS", providers.CanUseHTTPS)
 		setDoc("dual host", providers.DocDualHost, false)
 		setDoc("create-domains", providers.DocCreateDomains, true)
 
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/StackExchange/dnscontrol/v3/models"
 	"github.com/StackExchange/dnscontrol/v3/pkg/prettyzone"
@@ -54,12 +55,16 @@ The columns in --format=tsv are:
 
 The --ttl flag only applies to zone/js/djs formats.
 
+The --modified-since flag only gets the records created or changed after
+the given time, for providers that record when that happened.
+
 EXAMPLES:
    dnscontrol get-zones myr53 ROUTE53 example.com
    dnscontrol get-zones gmain GANDI_V5 example.com other.com
    dnscontrol get-zones cfmain CLOUDFLAREAPI all
    dnscontrol get-zones --format=tsv bind BIND example.com
-   dnscontrol get-zones --format=djs --out=draft.js glcoud GCLOUD example.com`,
+   dnscontrol get-zones --format=djs --out=draft.js glcoud GCLOUD example.com
+   dnscontrol get-zones --format=tsv --modified-since=2021-03-31T00:00:00Z hetzner HETZNER example.com`,
 	}
 }())
 
@@ -108,6 +113,7 @@ type GetZoneArgs struct {
 	OutputFormat       string   // Output format
 	OutputFile         string   // Filename to send output ("" means stdout)
 	DefaultTTL         int      // default TTL for providers where it is unknown
+	ModifiedSince      string   // Only get records changed after this time (RFC3339)
 }
 
 func (args *GetZoneArgs) flags() []cli.Flag {
@@ -128,6 +134,11 @@ func (args *GetZoneArgs) flags() []cli.Flag {
 		Destination: &args.DefaultTTL,
 		Usage:       `Default TTL (0 picks the zone's most common TTL)`,
 	})
+	flags = append(flags, &cli.StringFlag{
+		Name:        "modified-since",
+		Destination: &args.ModifiedSince,
+		Usage:       `Only get records changed after this time (RFC3339, e.g. 2021-03-31T00:00:00Z)`,
+	})
 	return flags
 }
 
@@ -178,15 +189,37 @@ func GetZone(args GetZoneArgs) error {
 
 	// fetch all of the records
 	zoneRecs := make([]models.Records, len(zones))
-	for i, zone := range zones {
-		recs, err := provider.GetZoneRecords(zone)
+	if args.ModifiedSince != "" {
+		since, err := time.Parse(time.RFC3339, args.ModifiedSince)
+		if err != nil {
+			return fmt.Errorf("failed GetZone: unexpected value for --modified-since: %w", err)
+		}
+		getter, ok := provider.(providers.ModifiedRecordsGetter)
+		if !ok {
+			return fmt.Errorf("provider type %s cannot get the records modified since a time", args.ProviderName)
+		}
+		for i, zone := range zones {
+			recs, err := getter.GetZoneRecordsModifiedSince(zone, since)
+			if err != nil {
+				return fmt.Errorf("failed GetZone gzrms: %w", err)
+			}
+			zoneRecs[i] = recs
+		}
+	} else if getter, ok := provider.(providers.ZonesRecordsGetter); ok {
+		zoneRecs, err = getter.GetZonesRecords(zones)
 		if err != nil {
 			return fmt.Errorf("failed GetZone gzr: %w", err)
 		}
-		zoneRecs[i] = recs
+	} else {
+		for i, zone := range zones {
+			recs, err := provider.GetZoneRecords(zone)
+			if err != nil {
+				return fmt.Errorf("failed GetZone gzr: %w", err)
+			}
+			zoneRecs[i] = recs
+		}
 	}
 
-
 	// Write the heading:
 
 	if args.OutputFormat == "js" || args.OutputFormat == "djs" {
@@ -271,8 +304,12 @@ func formatDsl(zonename string, rec *models.RecordConfig, defaultTTL uint32) str
 	switch rec.Type { // #rtype_variations
 	case "CAA":
 		return makeCaa(rec, ttlop)
+	case "HINFO":
+		target = fmt.Sprintf("'%s', '%s'", rec.GetTargetField(), rec.HinfoOS)
 	case "MX":
 		target = fmt.Sprintf("%d, '%s'", rec.MxPreference, rec.GetTargetField())
+	case "RP":
+		target = fmt.Sprintf("'%s', '%s'", rec.GetTargetField(), rec.RpTxt)
 	case "SSHFP":
 		target = fmt.Sprintf("%d, %d, '%s'", rec.SshfpAlgorithm, rec.SshfpFingerprint, rec.GetTargetField())
 	case "SOA":
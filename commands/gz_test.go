@@ -77,3 +77,19 @@ func testFormat(t *testing.T, domain, format string) {
 		t.Errorf("testFormat mismatch (-got +want):\n%s", diff.LineDiff(g, w))
 	}
 }
+
+func TestGetZoneModifiedSinceUnsupported(t *testing.T) {
+	gzargs := GetZoneArgs{
+		ZoneNames:     []string{"simple.com"},
+		OutputFormat:  "zone",
+		OutputFile:    os.DevNull,
+		CredName:      "bind",
+		ProviderName:  "BIND",
+		ModifiedSince: "2021-03-31T00:00:00Z",
+	}
+	gzargs.CredsFile = "test_data/bind-creds.json"
+
+	if err := GetZone(gzargs); err == nil {
+		t.Errorf("expected an error, BIND does not record when records change")
+	}
+}
@@ -0,0 +1,86 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/StackExchange/dnscontrol/v3/providers"
+	"github.com/StackExchange/dnscontrol/v3/providers/config"
+	"github.com/urfave/cli/v2"
+)
+
+var _ = cmd(catUtils, func() *cli.Command {
+	var args SelfTestArgs
+	return &cli.Command{
+		Name:  "self-test",
+		Usage: "checks that a provider accepts each record type it claims to support (stand-alone)",
+		Action: func(ctx *cli.Context) error {
+			if ctx.NArg() != 3 {
+				return cli.NewExitError("Arguments should be: credskey providername zone (Ex: hetzner HETZNER example.com)", 1)
+			}
+			args.CredName = ctx.Args().Get(0)
+			args.ProviderName = ctx.Args().Get(1)
+			args.ZoneName = ctx.Args().Get(2)
+			return exit(SelfTest(args))
+		},
+		Flags:     args.flags(),
+		UsageText: "dnscontrol self-test [command options] credkey provider zone",
+		Description: `Create a record of each supported type in a zone, then delete them again.  This is a stand-alone utility.
+
+The records are created below _dnscontrol-test, e.g. a._dnscontrol-test.
+Use a zone where that does not get in the way.
+
+ARGUMENTS:
+   credkey:  The name used in creds.json (first parameter to NewDnsProvider() in dnsconfig.js)
+   provider: The name of the provider (second parameter to NewDnsProvider() in dnsconfig.js)
+   zone:     The zone (domain) to create the records in
+
+EXAMPLES:
+   dnscontrol self-test hetzner HETZNER example.com`,
+	}
+}())
+
+// SelfTestArgs args required for the self-test subcommand.
+type SelfTestArgs struct {
+	GetCredentialsArgs        // Args related to creds.json
+	CredName           string // key in creds.json
+	ProviderName       string // provider name: HETZNER, etc
+	ZoneName           string // The zone to create the records in
+}
+
+func (args *SelfTestArgs) flags() []cli.Flag {
+	return args.GetCredentialsArgs.flags()
+}
+
+// SelfTest contains all data/flags needed to run self-test, independently of CLI.
+func SelfTest(args SelfTestArgs) error {
+	providerConfigs, err := config.LoadProviderConfigs(args.CredsFile)
+	if err != nil {
+		return fmt.Errorf("failed SelfTest lpc(%q): %w", args.CredsFile, err)
+	}
+	provider, err := providers.CreateDNSProvider(args.ProviderName, providerConfigs[args.CredName], nil)
+	if err != nil {
+		return fmt.Errorf("failed SelfTest cdp: %w", err)
+	}
+	tester, ok := provider.(providers.SelfTester)
+	if !ok {
+		return fmt.Errorf("provider type %s does not implement self-test", args.ProviderName)
+	}
+
+	results, err := tester.SelfTest(args.ZoneName)
+	failed := 0
+	for _, result := range results {
+		if result.Err != nil {
+			failed++
+			fmt.Printf("%-6s FAILED: %s\n", result.Type, result.Err)
+			continue
+		}
+		fmt.Printf("%-6s OK\n", result.Type)
+	}
+	if err != nil {
+		return fmt.Errorf("failed SelfTest: %w", err)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d record types failed", failed, len(results))
+	}
+	return nil
+}
@@ -70,8 +70,14 @@ func RRtoRC(rr dns.RR, origin string) RecordConfig {
 		panicInvalid(rc.SetTargetCAA(v.Flag, v.Tag, v.Value))
 	case *dns.CNAME:
 		panicInvalid(rc.SetTarget(v.Target))
+	case *dns.DHCID:
+		panicInvalid(rc.SetTarget(v.Digest))
 	case *dns.DS:
 		panicInvalid(rc.SetTargetDS(v.KeyTag, v.Algorithm, v.DigestType, v.Digest))
+	case *dns.HINFO:
+		panicInvalid(rc.SetTargetHINFO(v.Cpu, v.Os))
+	case *dns.LOC:
+		panicInvalid(rc.SetTargetLOC(v.Version, v.Size, v.HorizPre, v.VertPre, v.Latitude, v.Longitude, v.Altitude))
 	case *dns.MX:
 		panicInvalid(rc.SetTargetMX(v.Preference, v.Mx))
 	case *dns.NS:
@@ -80,8 +86,14 @@ func RRtoRC(rr dns.RR, origin string) RecordConfig {
 		panicInvalid(rc.SetTarget(v.Ptr))
 	case *dns.NAPTR:
 		panicInvalid(rc.SetTargetNAPTR(v.Order, v.Preference, v.Flags, v.Service, v.Regexp, v.Replacement))
+	case *dns.RP:
+		panicInvalid(rc.SetTargetRP(v.Mbox, v.Txt))
 	case *dns.SOA:
 		panicInvalid(rc.SetTargetSOA(v.Ns, v.Mbox, v.Serial, v.Refresh, v.Retry, v.Expire, v.Minttl))
+	case *dns.SVCB:
+		panicInvalid(rc.SetTargetSVCB(v.Priority, v.Target, v.Value))
+	case *dns.HTTPS:
+		panicInvalid(rc.SetTargetSVCB(v.Priority, v.Target, v.Value))
 	case *dns.SRV:
 		panicInvalid(rc.SetTargetSRV(v.Priority, v.Weight, v.Port, v.Target))
 	case *dns.SSHFP:
@@ -78,16 +78,26 @@ func (dc *DomainConfig) Punycode() error {
 
 		// Set the target:
 		switch rec.Type { // #rtype_variations
-		case "ALIAS", "MX", "NS", "CNAME", "PTR", "SRV", "URL", "URL301", "FRAME", "R53_ALIAS", "NS1_URLFWD":
+		case "ALIAS", "MX", "NS", "CNAME", "PTR", "SRV", "URL", "URL301", "FRAME", "R53_ALIAS", "NS1_URLFWD", "SVCB", "HTTPS":
 			// These rtypes are hostnames, therefore need to be converted (unlike, for example, an AAAA record)
 			t, err := idna.ToASCII(rec.GetTargetField())
 			if err != nil {
 				return err
 			}
 			rec.SetTarget(t)
+		case "RP":
+			mbox, err := idna.ToASCII(rec.GetTargetField())
+			if err != nil {
+				return err
+			}
+			txt, err := idna.ToASCII(rec.RpTxt)
+			if err != nil {
+				return err
+			}
+			rec.SetTargetRP(mbox, txt)
 		case "CF_REDIRECT", "CF_TEMP_REDIRECT":
 			rec.SetTarget(rec.GetTargetField())
-		case "A", "AAAA", "CAA", "DS", "NAPTR", "SOA", "SSHFP", "TXT", "TLSA", "AZURE_ALIAS":
+		case "A", "AAAA", "CAA", "DHCID", "DS", "HINFO", "LOC", "NAPTR", "SOA", "SSHFP", "TXT", "TLSA", "AZURE_ALIAS":
 			// Nothing to do.
 		default:
 			return fmt.Errorf("Punycode rtype %v unimplemented", rec.Type)
@@ -1,6 +1,8 @@
 package models
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"log"
 	"sort"
@@ -86,6 +88,17 @@ type RecordConfig struct {
 	DsAlgorithm      uint8             `json:"dsalgorithm,omitempty"`
 	DsDigestType     uint8             `json:"dsdigesttype,omitempty"`
 	DsDigest         string            `json:"dsdigest,omitempty"`
+	HinfoOS          string            `json:"hinfoos,omitempty"`
+	RpTxt            string            `json:"rptxt,omitempty"`
+	SvcPriority      uint16            `json:"svcpriority,omitempty"`
+	SvcParams        string            `json:"svcparams,omitempty"`
+	LocVersion       uint8             `json:"locversion,omitempty"`
+	LocSize          uint8             `json:"locsize,omitempty"`
+	LocHorizPre      uint8             `json:"lochorizpre,omitempty"`
+	LocVertPre       uint8             `json:"locvertpre,omitempty"`
+	LocLatitude      uint32            `json:"loclatitude,omitempty"`
+	LocLongitude     uint32            `json:"loclongitude,omitempty"`
+	LocAltitude      uint32            `json:"localtitude,omitempty"`
 	NaptrOrder       uint16            `json:"naptrorder,omitempty"`
 	NaptrPreference  uint16            `json:"naptrpreference,omitempty"`
 	NaptrFlags       string            `json:"naptrflags,omitempty"`
@@ -222,6 +235,21 @@ func (rc *RecordConfig) ToDiffable(extraMaps ...map[string]string) string {
 	return content
 }
 
+// Fingerprint returns a hash of the label, type, TTL and target of the
+// record. Records that only differ in the case of their label or of the
+// hostnames in their target have the same fingerprint.
+func (rc *RecordConfig) Fingerprint() string {
+	target := rc.GetTargetCombined()
+	switch rc.Type { // #rtype_variations
+	case "ALIAS", "ANAME", "CNAME", "MX", "NS", "PTR", "SRV":
+		// Everything but the hostname is numeric.
+		target = strings.ToLower(target)
+	}
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00%s\x00%d\x00%s", strings.ToLower(rc.GetLabelFQDN()), strings.ToUpper(rc.Type), rc.TTL, target)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
 // ToRR converts a RecordConfig to a dns.RR.
 func (rc *RecordConfig) ToRR() dns.RR {
 
@@ -251,13 +279,37 @@ func (rc *RecordConfig) ToRR() dns.RR {
 		rr.(*dns.AAAA).AAAA = rc.GetTargetIP()
 	case dns.TypeCNAME:
 		rr.(*dns.CNAME).Target = rc.GetTargetField()
+	case dns.TypeDHCID:
+		rr.(*dns.DHCID).Digest = rc.GetTargetField()
 	case dns.TypeDS:
 		rr.(*dns.DS).Algorithm = rc.DsAlgorithm
 		rr.(*dns.DS).DigestType = rc.DsDigestType
 		rr.(*dns.DS).Digest = rc.DsDigest
 		rr.(*dns.DS).KeyTag = rc.DsKeyTag
+	case dns.TypeHINFO:
+		rr.(*dns.HINFO).Cpu = rc.GetTargetField()
+		rr.(*dns.HINFO).Os = rc.HinfoOS
+	case dns.TypeSVCB:
+		rr.(*dns.SVCB).Priority = rc.SvcPriority
+		rr.(*dns.SVCB).Target = rc.GetTargetField()
+		rr.(*dns.SVCB).Value = rc.svcParams()
+	case dns.TypeHTTPS:
+		rr.(*dns.HTTPS).Priority = rc.SvcPriority
+		rr.(*dns.HTTPS).Target = rc.GetTargetField()
+		rr.(*dns.HTTPS).Value = rc.svcParams()
+	case dns.TypeLOC:
+		rr.(*dns.LOC).Version = rc.LocVersion
+		rr.(*dns.LOC).Size = rc.LocSize
+		rr.(*dns.LOC).HorizPre = rc.LocHorizPre
+		rr.(*dns.LOC).VertPre = rc.LocVertPre
+		rr.(*dns.LOC).Latitude = rc.LocLatitude
+		rr.(*dns.LOC).Longitude = rc.LocLongitude
+		rr.(*dns.LOC).Altitude = rc.LocAltitude
 	case dns.TypePTR:
 		rr.(*dns.PTR).Ptr = rc.GetTargetField()
+	case dns.TypeRP:
+		rr.(*dns.RP).Mbox = rc.GetTargetField()
+		rr.(*dns.RP).Txt = rc.RpTxt
 	case dns.TypeNAPTR:
 		rr.(*dns.NAPTR).Order = rc.NaptrOrder
 		rr.(*dns.NAPTR).Preference = rc.NaptrPreference
@@ -393,6 +445,70 @@ func (recs Records) GroupedByFQDN() ([]string, map[string]Records) {
 	return order, groups
 }
 
+// TTLMismatchFactor is how many times larger than the smallest TTL at a
+// label the largest TTL may be before CheckTTLConsistency complains.
+const TTLMismatchFactor = 10
+
+// CheckTTLConsistency returns an error for each label whose record types
+// have wildly different TTLs. This is permitted (only records of the same
+// type must agree) but is often unintentional, so callers should treat
+// these as warnings.
+func (recs Records) CheckTTLConsistency() (errs []error) {
+	order, groups := recs.GroupedByFQDN()
+	for _, label := range order {
+		// The smallest and largest TTL of each type.
+		var types []string
+		lo, hi := map[string]*RecordConfig{}, map[string]*RecordConfig{}
+		for _, rec := range groups[label] {
+			if lo[rec.Type] == nil {
+				types = append(types, rec.Type)
+			}
+			if lo[rec.Type] == nil || rec.TTL < lo[rec.Type].TTL {
+				lo[rec.Type] = rec
+			}
+			if hi[rec.Type] == nil || rec.TTL > hi[rec.Type].TTL {
+				hi[rec.Type] = rec
+			}
+		}
+		// Compare across types only, and report the widest difference.
+		var low, high *RecordConfig
+		for _, t1 := range types {
+			for _, t2 := range types {
+				if t1 == t2 || uint64(hi[t2].TTL) <= uint64(lo[t1].TTL)*TTLMismatchFactor {
+					continue
+				}
+				if low == nil || uint64(hi[t2].TTL)*uint64(low.TTL) > uint64(high.TTL)*uint64(lo[t1].TTL) {
+					low, high = lo[t1], hi[t2]
+				}
+			}
+		}
+		if low != nil {
+			errs = append(errs, fmt.Errorf("TTLs at label %s differ widely: %s has ttl=%d but %s has ttl=%d", label, low.Type, low.TTL, high.Type, high.TTL))
+		}
+	}
+	return errs
+}
+
+// CheckCNAMEs returns an error for each label that has more than one
+// CNAME, or a CNAME and records of other types. RFC 1034 forbids both.
+func (recs Records) CheckCNAMEs() (errs []error) {
+	cnames := map[string]bool{}
+	for _, r := range recs {
+		if r.Type == "CNAME" {
+			if cnames[r.GetLabel()] {
+				errs = append(errs, fmt.Errorf("cannot have multiple CNAMEs with same name: %s", r.GetLabelFQDN()))
+			}
+			cnames[r.GetLabel()] = true
+		}
+	}
+	for _, r := range recs {
+		if cnames[r.GetLabel()] && r.Type != "CNAME" {
+			errs = append(errs, fmt.Errorf("cannot have CNAME and %s record with same name: %s", r.Type, r.GetLabelFQDN()))
+		}
+	}
+	return errs
+}
+
 // PostProcessRecords does any post-processing of the downloaded DNS records.
 func PostProcessRecords(recs []*RecordConfig) {
 	downcase(recs)
@@ -404,10 +520,14 @@ func downcase(recs []*RecordConfig) {
 		r.Name = strings.ToLower(r.Name)
 		r.NameFQDN = strings.ToLower(r.NameFQDN)
 		switch r.Type { // #rtype_variations
-		case "ANAME", "CNAME", "DS", "MX", "NS", "PTR", "NAPTR", "SRV":
+		case "ANAME", "CNAME", "DS", "MX", "NS", "PTR", "NAPTR", "SRV", "SVCB", "HTTPS":
 			// These record types have a target that is case insensitive, so we downcase it.
 			r.Target = strings.ToLower(r.Target)
-		case "A", "AAAA", "ALIAS", "CAA", "IMPORT_TRANSFORM", "TLSA", "TXT", "SSHFP", "CF_REDIRECT", "CF_TEMP_REDIRECT":
+		case "RP":
+			// Both the mailbox and the TXT domain are names.
+			r.Target = strings.ToLower(r.Target)
+			r.RpTxt = strings.ToLower(r.RpTxt)
+		case "A", "AAAA", "ALIAS", "CAA", "DHCID", "HINFO", "IMPORT_TRANSFORM", "LOC", "TLSA", "TXT", "SSHFP", "CF_REDIRECT", "CF_TEMP_REDIRECT":
 			// These record types have a target that is case sensitive, or is an IP address. We leave them alone.
 			// Do nothing.
 		case "SOA":
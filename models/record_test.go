@@ -49,3 +49,94 @@ func TestKey(t *testing.T) {
 		}
 	}
 }
+
+func TestCheckTTLConsistency(t *testing.T) {
+	mk := func(label, rtype string, ttl uint32) *RecordConfig {
+		rc := &RecordConfig{Type: rtype, TTL: ttl}
+		rc.SetLabel(label, "example.com")
+		return rc
+	}
+	var tests = []struct {
+		recs     Records
+		expected int
+	}{
+		{Records{mk("www", "A", 300), mk("www", "AAAA", 600)}, 0},
+		{Records{mk("www", "A", 300), mk("www", "A", 86400)}, 0}, // same rrset, caught elsewhere
+		{Records{mk("www", "A", 300), mk("www", "TXT", 86400)}, 1},
+		{Records{mk("www", "A", 300), mk("www", "TXT", 86400), mk("@", "MX", 60), mk("@", "TXT", 3600)}, 2},
+		{Records{mk("www", "A", 300), mk("mail", "TXT", 86400)}, 0},
+		{Records{mk("www", "A", 60), mk("www", "A", 86400), mk("www", "TXT", 3600)}, 1}, // extremes share a type
+		{Records{mk("www", "A", 430000000), mk("www", "TXT", 440000000)}, 0},            // no overflow
+		{Records{mk("www", "A", 0), mk("www", "TXT", 300)}, 1},
+	}
+	for i, test := range tests {
+		actual := test.recs.CheckTTLConsistency()
+		if len(actual) != test.expected {
+			t.Errorf("%d: Expected %d warnings, got %v", i, test.expected, actual)
+		}
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	mx := func(label string, ttl uint32, target string) *RecordConfig {
+		rc := &RecordConfig{Type: "MX", TTL: ttl}
+		rc.SetLabel(label, "example.com")
+		if err := rc.SetTargetMX(10, target); err != nil {
+			t.Fatal(err)
+		}
+		return rc
+	}
+
+	a := mx("@", 300, "mail.example.com.")
+	if b := mx("@", 300, "Mail.EXAMPLE.com."); a.Fingerprint() != b.Fingerprint() {
+		t.Errorf("expected hostnames differing in case only to have the same fingerprint")
+	}
+	if b := mx("@", 300, "mail.example.com."); a.Fingerprint() != b.Fingerprint() {
+		t.Errorf("expected identical records to have the same fingerprint")
+	}
+	if b := mx("@", 3600, "mail.example.com."); a.Fingerprint() == b.Fingerprint() {
+		t.Errorf("expected a TTL change to change the fingerprint")
+	}
+	if b := mx("www", 300, "mail.example.com."); a.Fingerprint() == b.Fingerprint() {
+		t.Errorf("expected a label change to change the fingerprint")
+	}
+
+	txt := &RecordConfig{Type: "TXT", TTL: 300}
+	txt.SetLabel("@", "example.com")
+	txt.SetTargetTXT("Hello")
+	other := &RecordConfig{Type: "TXT", TTL: 300}
+	other.SetLabel("@", "example.com")
+	other.SetTargetTXT("hello")
+	if txt.Fingerprint() == other.Fingerprint() {
+		t.Errorf("expected TXT records differing in case to have different fingerprints")
+	}
+}
+
+func TestCheckCNAMEs(t *testing.T) {
+	rc := func(label, rtype, target string) *RecordConfig {
+		r := &RecordConfig{Type: rtype, TTL: 300}
+		r.SetLabel(label, "example.com")
+		r.SetTarget(target)
+		return r
+	}
+
+	valid := Records{
+		rc("www", "CNAME", "web.example.net."),
+		rc("mail", "A", "1.2.3.4"),
+		rc("mail", "MX", "mail.example.com."),
+	}
+	if errs := valid.CheckCNAMEs(); len(errs) != 0 {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+
+	conflicting := append(valid, rc("www", "A", "1.2.3.4"))
+	errs := conflicting.CheckCNAMEs()
+	if len(errs) != 1 || errs[0].Error() != "cannot have CNAME and A record with same name: www.example.com" {
+		t.Errorf("expected an error for the CNAME and A at www; got=%v", errs)
+	}
+
+	duplicate := append(valid, rc("www", "CNAME", "other.example.net."))
+	if errs := duplicate.CheckCNAMEs(); len(errs) != 1 {
+		t.Errorf("expected an error for two CNAMEs at www; got=%v", errs)
+	}
+}
@@ -2,6 +2,7 @@ package models
 
 import (
 	"fmt"
+	"net/url"
 	"strconv"
 	"strings"
 )
@@ -43,3 +44,32 @@ func (rc *RecordConfig) SetTargetCAAString(s string) error {
 	}
 	return rc.SetTargetCAAStrings(part[0], part[1], StripQuotes(part[2]))
 }
+
+// ValidateCAA returns an error if the CAA record is invalid.
+// The value of an iodef must be a mailto:, http: or https: URI (RFC 8659).
+func ValidateCAA(rc *RecordConfig) error {
+	if rc.Type != "CAA" {
+		return fmt.Errorf("rc.Type=%q, expecting CAA", rc.Type)
+	}
+	if rc.CaaTag != "iodef" {
+		return nil
+	}
+	target := rc.GetTargetField()
+	u, err := url.Parse(target)
+	if err != nil {
+		return fmt.Errorf("CAA iodef (%v) is not a valid URI: %w", target, err)
+	}
+	switch u.Scheme {
+	case "mailto":
+		if !strings.Contains(u.Opaque, "@") {
+			return fmt.Errorf("CAA iodef (%v) is not a valid mailto: URI", target)
+		}
+	case "http", "https":
+		if u.Host == "" {
+			return fmt.Errorf("CAA iodef (%v) has no host", target)
+		}
+	default:
+		return fmt.Errorf("CAA iodef (%v) must be a mailto:, http: or https: URI", target)
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package models
+
+import "testing"
+
+func TestValidateCAA(t *testing.T) {
+	tests := []struct {
+		tag    string
+		target string
+		valid  bool
+	}{
+		{"issue", "letsencrypt.org", true},
+		{"iodef", "mailto:security@example.com", true},
+		{"iodef", "https://example.com/caa-report", true},
+		{"iodef", "http://example.com", true},
+		{"iodef", "security@example.com", false},
+		{"iodef", "mailto:example.com", false},
+		{"iodef", "ftp://example.com", false},
+		{"iodef", "https://", false},
+		{"iodef", "https://exa mple.com/%zz", false},
+	}
+	for i, test := range tests {
+		rc := &RecordConfig{Type: "CAA"}
+		rc.SetTargetCAA(0, test.tag, test.target)
+		err := ValidateCAA(rc)
+		if test.valid != (err == nil) {
+			t.Errorf("%v: expected valid=%v got (%v) (%q)", i, test.valid, err, test.target)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+// SetTargetDHCID sets the DHCID digest, which is stored in .Target.
+// Whitespace within the base64 digest is removed, as zone files may
+// split long digests.
+func (rc *RecordConfig) SetTargetDHCID(digest string) error {
+	rc.SetTarget(strings.Join(strings.Fields(digest), ""))
+	if rc.Type == "" {
+		rc.Type = "DHCID"
+	}
+	if rc.Type != "DHCID" {
+		panic("assertion failed: SetTargetDHCID called when .Type is not DHCID")
+	}
+	return nil
+}
+
+// ValidateDHCID returns an error if the DHCID record is invalid.
+// The digest must be base64 encoded (RFC 4701).
+func ValidateDHCID(rc *RecordConfig) error {
+	if rc.Type != "DHCID" {
+		return fmt.Errorf("rc.Type=%q, expecting DHCID", rc.Type)
+	}
+	target := rc.GetTargetField()
+	if target == "" {
+		return fmt.Errorf("DHCID digest is empty")
+	}
+	if _, err := base64.StdEncoding.DecodeString(target); err != nil {
+		return fmt.Errorf("DHCID digest (%v) is not valid base64: %w", target, err)
+	}
+	return nil
+}
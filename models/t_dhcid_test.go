@@ -0,0 +1,42 @@
+package models
+
+import "testing"
+
+func TestDHCIDRoundTrip(t *testing.T) {
+	const digest = "AAIBY2/AuCccgoJbsaxcQc9TUapptP69lOjxfNuVAA2kjEA="
+
+	rc := &RecordConfig{Type: "DHCID"}
+	rc.SetLabel("host", "example.com")
+	if err := rc.PopulateFromString("DHCID", "AAIBY2/AuCccgoJbsaxcQc9TUapptP69l OjxfNuVAA2kjEA=", "example.com"); err != nil {
+		t.Fatal(err)
+	}
+	if rc.GetTargetField() != digest {
+		t.Errorf("expected the digest without whitespace; got=%q", rc.GetTargetField())
+	}
+	if rc.GetTargetCombined() != digest {
+		t.Errorf("expected the digest as the combined target; got=%q", rc.GetTargetCombined())
+	}
+
+	back := RRtoRC(rc.ToRR(), "example.com")
+	if back.Type != "DHCID" || back.GetTargetField() != digest {
+		t.Errorf("record changed in round-trip; got=%s %q", back.Type, back.GetTargetField())
+	}
+}
+
+func TestValidateDHCID(t *testing.T) {
+	tests := []struct {
+		digest string
+		valid  bool
+	}{
+		{"AAIBY2/AuCccgoJbsaxcQc9TUapptP69lOjxfNuVAA2kjEA=", true},
+		{"not base64!", false},
+		{"", false},
+	}
+	for i, test := range tests {
+		rc := &RecordConfig{Type: "DHCID"}
+		rc.SetTargetDHCID(test.digest)
+		if err := ValidateDHCID(rc); test.valid != (err == nil) {
+			t.Errorf("%v: expected valid=%v got (%v) (%q)", i, test.valid, err, test.digest)
+		}
+	}
+}
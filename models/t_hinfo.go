@@ -0,0 +1,37 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/miekg/dns"
+)
+
+// SetTargetHINFO sets the HINFO fields. The CPU is stored in .Target.
+func (rc *RecordConfig) SetTargetHINFO(cpu, os string) error {
+	rc.SetTarget(cpu)
+	rc.HinfoOS = os
+	if rc.Type == "" {
+		rc.Type = "HINFO"
+	}
+	if rc.Type != "HINFO" {
+		panic("assertion failed: SetTargetHINFO called when .Type is not HINFO")
+	}
+	return nil
+}
+
+// SetTargetHINFOString is like SetTargetHINFO but accepts one big string.
+// Strings containing spaces must be quoted.
+// Ex: `"Intel Xeon" Linux`
+func (rc *RecordConfig) SetTargetHINFOString(s string) error {
+	rr, err := dns.NewRR(". HINFO " + s)
+	if err != nil || rr == nil {
+		return fmt.Errorf("HINFO value does not contain 2 fields: (%#v)", s)
+	}
+	hinfo := rr.(*dns.HINFO)
+	if hinfo.Os == "" && !strings.HasSuffix(strings.TrimSpace(s), `""`) {
+		// The parser quietly accepts a missing OS.
+		return fmt.Errorf("HINFO value does not contain 2 fields: (%#v)", s)
+	}
+	return rc.SetTargetHINFO(hinfo.Cpu, hinfo.Os)
+}
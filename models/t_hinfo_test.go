@@ -0,0 +1,27 @@
+package models
+
+import "testing"
+
+func TestSetTargetHINFOString(t *testing.T) {
+	tests := []struct {
+		given   string
+		cpu, os string
+		valid   bool
+	}{
+		{`Intel Linux`, "Intel", "Linux", true},
+		{`"Intel" "Linux"`, "Intel", "Linux", true},
+		{`"Intel Xeon" "Debian Linux"`, "Intel Xeon", "Debian Linux", true},
+		{`Intel`, "", "", false},
+	}
+	for i, test := range tests {
+		rc := &RecordConfig{Type: "HINFO"}
+		err := rc.SetTargetHINFOString(test.given)
+		if test.valid != (err == nil) {
+			t.Errorf("%v: expected valid=%v got (%v) (%q)", i, test.valid, err, test.given)
+			continue
+		}
+		if test.valid && (rc.GetTargetField() != test.cpu || rc.HinfoOS != test.os) {
+			t.Errorf("%v: expected cpu=%q os=%q got cpu=%q os=%q", i, test.cpu, test.os, rc.GetTargetField(), rc.HinfoOS)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"fmt"
+
+	"github.com/miekg/dns"
+)
+
+// SetTargetLOC sets the LOC fields. The values are in the wire format
+// described in RFC 1876: sizes and precisions are encoded as mantissa
+// and exponent, latitude, longitude and altitude are offsets in
+// thousandths of an arc second and centimeters.
+func (rc *RecordConfig) SetTargetLOC(version, size, horizPre, vertPre uint8, latitude, longitude, altitude uint32) error {
+	rc.LocVersion = version
+	rc.LocSize = size
+	rc.LocHorizPre = horizPre
+	rc.LocVertPre = vertPre
+	rc.LocLatitude = latitude
+	rc.LocLongitude = longitude
+	rc.LocAltitude = altitude
+
+	if rc.Type == "" {
+		rc.Type = "LOC"
+	}
+	if rc.Type != "LOC" {
+		panic("assertion failed: SetTargetLOC called when .Type is not LOC")
+	}
+
+	return nil
+}
+
+// SetTargetLOCString is like SetTargetLOC but accepts the presentation
+// format. The size and precisions are optional.
+// Ex: `52 22 23.000 N 4 53 32.000 E -2.00m 0.00m 10000m 10m`
+func (rc *RecordConfig) SetTargetLOCString(s string) error {
+	rr, err := dns.NewRR(". LOC " + s)
+	if err != nil || rr == nil {
+		return fmt.Errorf("LOC value is not valid: (%#v)", s)
+	}
+	loc := rr.(*dns.LOC)
+	return rc.SetTargetLOC(loc.Version, loc.Size, loc.HorizPre, loc.VertPre, loc.Latitude, loc.Longitude, loc.Altitude)
+}
@@ -0,0 +1,40 @@
+package models
+
+import "testing"
+
+func TestLOCRoundTrip(t *testing.T) {
+	tests := []struct {
+		given, want string
+	}{
+		{`52 22 23.000 N 4 53 32.000 E -2.00m 0.00m 10000m 10m`, `52 22 23.000 N 04 53 32.000 E -2m 0.00m 10000m 10m`},
+		{`42 21 54 N 71 06 18 W -24m 30m`, `42 21 54.000 N 71 06 18.000 W -24m 30m 10000m 10m`},
+	}
+	for i, test := range tests {
+		rc := &RecordConfig{Type: "LOC"}
+		rc.SetLabel("@", "example.com")
+		if err := rc.PopulateFromString("LOC", test.given, "example.com"); err != nil {
+			t.Fatalf("%v: %v", i, err)
+		}
+		if got := rc.GetTargetCombined(); got != test.want {
+			t.Errorf("%v: expected %q got %q", i, test.want, got)
+		}
+
+		again := &RecordConfig{Type: "LOC"}
+		if err := again.SetTargetLOCString(rc.GetTargetCombined()); err != nil {
+			t.Fatalf("%v: %v", i, err)
+		}
+		if again.GetTargetCombined() != rc.GetTargetCombined() {
+			t.Errorf("%v: value changed in round-trip; got=%q want=%q", i, again.GetTargetCombined(), rc.GetTargetCombined())
+		}
+
+		back := RRtoRC(rc.ToRR(), "example.com")
+		if back.LocLatitude != rc.LocLatitude || back.LocLongitude != rc.LocLongitude || back.LocAltitude != rc.LocAltitude || back.LocSize != rc.LocSize {
+			t.Errorf("%v: fields changed in round-trip; got=%+v", i, back)
+		}
+	}
+
+	rc := &RecordConfig{Type: "LOC"}
+	if err := rc.SetTargetLOCString("not a location"); err == nil {
+		t.Errorf("expected an error for an invalid LOC value")
+	}
+}
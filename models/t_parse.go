@@ -10,6 +10,9 @@ import (
 // string (all the parameters of an MX, SRV, CAA, etc). Rather than have
 // each provider rewrite this code many times, here's a helper function to use.
 //
+// It is the reverse of GetTargetCombined: the combined target of any
+// rtype it supports can be parsed back into the same record.
+//
 // If this doesn't work for all rtypes, process the special cases then
 // call this for the remainder.
 func (r *RecordConfig) PopulateFromString(rtype, contents, origin string) error {
@@ -33,16 +36,26 @@ func (r *RecordConfig) PopulateFromString(rtype, contents, origin string) error
 		return r.SetTarget(contents)
 	case "CAA":
 		return r.SetTargetCAAString(contents)
+	case "DHCID":
+		return r.SetTargetDHCID(contents)
 	case "DS":
 		return r.SetTargetDSString(contents)
+	case "HINFO":
+		return r.SetTargetHINFOString(contents)
+	case "LOC":
+		return r.SetTargetLOCString(contents)
 	case "MX":
 		return r.SetTargetMXString(contents)
 	case "NAPTR":
 		return r.SetTargetNAPTRString(contents)
+	case "RP":
+		return r.SetTargetRPString(contents)
 	case "SRV":
 		return r.SetTargetSRVString(contents)
 	case "SOA":
 		return r.SetTargetSOAString(contents)
+	case "SVCB", "HTTPS":
+		return r.SetTargetSVCBString(contents)
 	case "SSHFP":
 		return r.SetTargetSSHFPString(contents)
 	case "TLSA":
@@ -54,3 +67,21 @@ func (r *RecordConfig) PopulateFromString(rtype, contents, origin string) error
 			rtype, contents, origin)
 	}
 }
+
+// PopulateFromStringFunc is like PopulateFromString, but the contents of
+// TXT and SPF records are split into their strings by txtFn. This is for
+// providers whose quoting differs from the one PopulateFromString expects.
+func (r *RecordConfig) PopulateFromStringFunc(rtype, contents, origin string, txtFn func(s string) ([]string, error)) error {
+	if txtFn == nil || (rtype != "TXT" && rtype != "SPF") {
+		return r.PopulateFromString(rtype, contents, origin)
+	}
+	if r.Type != "" && r.Type != rtype {
+		panic(fmt.Errorf("assertion failed: rtype already set (%s) (%s)", rtype, r.Type))
+	}
+	r.Type = rtype
+	txts, err := txtFn(contents)
+	if err != nil {
+		return err
+	}
+	return r.SetTargetTXTs(txts)
+}
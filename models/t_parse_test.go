@@ -0,0 +1,75 @@
+package models
+
+import "testing"
+
+func TestPopulateFromString_hostnames(t *testing.T) {
+	// Hostnames are used as given, whether absolute or relative to the
+	// origin. Providers resolve them as their API defines.
+	tests := []struct {
+		rtype, contents string
+		target          string
+	}{
+		{"CNAME", "web.example.com.", "web.example.com."},
+		{"CNAME", "mail.sub", "mail.sub"},
+		{"CNAME", "@", "@"},
+		{"NS", "ns1.example.net.", "ns1.example.net."},
+		{"MX", "10 mail.sub", "mail.sub"},
+		{"SRV", "10 60 5060 sip.sub", "sip.sub"},
+	}
+	for i, test := range tests {
+		rc := &RecordConfig{}
+		if err := rc.PopulateFromString(test.rtype, test.contents, "example.com"); err != nil {
+			t.Errorf("%v: %v", i, err)
+			continue
+		}
+		if rc.GetTargetField() != test.target {
+			t.Errorf("%v: expected %s target %q got %q", i, test.rtype, test.target, rc.GetTargetField())
+		}
+	}
+}
+
+func TestPopulateFromString_roundTrip(t *testing.T) {
+	// PopulateFromString is the reverse of GetTargetCombined.
+	tests := []struct {
+		rtype, contents string
+	}{
+		{"A", "192.0.2.1"},
+		{"AAAA", "2001:db8::1"},
+		{"ANAME", "lb.example.net."},
+		{"CAA", `0 issue "letsencrypt.org"`},
+		{"CAA", `128 iodef "mailto:security@example.com"`},
+		{"CNAME", "www.example.net."},
+		{"DHCID", "AAIBY2/AuCccgoJbsaxcQc9TUapptP69lOjxfNuVAA2kjEA="},
+		{"DS", "12345 13 2 ABCDEF0123456789"},
+		{"HINFO", `"Intel" "Linux"`},
+		{"LOC", "52 22 23.000 N 4 53 32.000 E -2.00m 0.00m 10000.00m 10.00m"},
+		{"MX", "10 mail.example.net."},
+		{"NAPTR", `100 10 "U" "E2U+sip" "!^.*$!sip:info@example.com!" .`},
+		{"NS", "ns1.example.net."},
+		{"PTR", "www.example.net."},
+		{"RP", "hostmaster.example.net. txt.example.net."},
+		{"SOA", "ns1.example.net. hostmaster.example.net. 2021040101 3600 600 604800 1440"},
+		{"SRV", "10 60 5060 sip.example.net."},
+		{"SSHFP", "1 2 ABCDEF0123456789"},
+		{"SVCB", `1 . alpn="h2,h3"`},
+		{"HTTPS", `1 . alpn="h2,h3"`},
+		{"TLSA", "3 1 1 ABCDEF0123456789"},
+		{"TXT", "v=spf1 -all"},
+	}
+	for _, test := range tests {
+		rc := &RecordConfig{}
+		if err := rc.PopulateFromString(test.rtype, test.contents, "example.com"); err != nil {
+			t.Errorf("%s: %v", test.rtype, err)
+			continue
+		}
+		combined := rc.GetTargetCombined()
+		again := &RecordConfig{}
+		if err := again.PopulateFromString(test.rtype, combined, "example.com"); err != nil {
+			t.Errorf("%s: cannot parse %q: %v", test.rtype, combined, err)
+			continue
+		}
+		if got := again.GetTargetCombined(); got != combined {
+			t.Errorf("%s: expected %q; got=%q", test.rtype, combined, got)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ValidatePTRLabel returns an error if rc is a PTR record in the reverse
+// zone origin (in-addr.arpa or ip6.arpa) whose name does not map to an
+// IP address. PTR records in other zones are not checked.
+func ValidatePTRLabel(rc *RecordConfig, origin string) error {
+	if rc.Type != "PTR" {
+		return fmt.Errorf("rc.Type=%q, expecting PTR", rc.Type)
+	}
+	name := strings.ToLower(strings.TrimSuffix(rc.GetLabelFQDN(), "."))
+	origin = strings.ToLower(strings.TrimSuffix(origin, "."))
+
+	switch {
+	case strings.HasSuffix(origin, ".in-addr.arpa") || origin == "in-addr.arpa":
+		octets := strings.Split(strings.TrimSuffix(name, ".in-addr.arpa"), ".")
+		octets = removeClasslessLabels(octets)
+		if len(octets) != 4 {
+			return fmt.Errorf("PTR %s does not map to an IPv4 address: expected 4 octets, found %d", name, len(octets))
+		}
+		for _, o := range octets {
+			if _, err := strconv.ParseUint(o, 10, 8); err != nil || (len(o) > 1 && o[0] == '0') {
+				return fmt.Errorf("PTR %s does not map to an IPv4 address: %q is not an octet", name, o)
+			}
+		}
+		// The first label is the last octet of the address.
+		last, _ := strconv.ParseUint(octets[0], 10, 8)
+		if err := checkClasslessRange(last, origin); err != nil {
+			return fmt.Errorf("PTR %s: %w", name, err)
+		}
+	case strings.HasSuffix(origin, ".ip6.arpa") || origin == "ip6.arpa":
+		nibbles := strings.Split(strings.TrimSuffix(name, ".ip6.arpa"), ".")
+		if len(nibbles) != 32 {
+			return fmt.Errorf("PTR %s does not map to an IPv6 address: expected 32 nibbles, found %d", name, len(nibbles))
+		}
+		for _, n := range nibbles {
+			if len(n) != 1 || !strings.Contains("0123456789abcdef", n) {
+				return fmt.Errorf("PTR %s does not map to an IPv6 address: %q is not a nibble", name, n)
+			}
+		}
+	}
+	return nil
+}
+
+// removeClasslessLabels removes the labels of RFC 2317 classless
+// delegations, such as "128/27" in 27.128/27.18.20.172.in-addr.arpa.
+func removeClasslessLabels(labels []string) []string {
+	var result []string
+	for _, l := range labels {
+		if !strings.Contains(l, "/") {
+			result = append(result, l)
+		}
+	}
+	return result
+}
+
+// checkClasslessRange returns an error if the last octet of an address
+// is outside the range of the RFC 2317 classless zone origin, if any.
+func checkClasslessRange(octet uint64, origin string) error {
+	first := strings.SplitN(origin, ".", 2)[0]
+	parts := strings.Split(first, "/")
+	if len(parts) != 2 {
+		return nil
+	}
+	start, err1 := strconv.ParseUint(parts[0], 10, 8)
+	bits, err2 := strconv.ParseUint(parts[1], 10, 8)
+	if err1 != nil || err2 != nil || bits < 24 || bits > 32 {
+		return nil
+	}
+	size := uint64(1) << (32 - bits)
+	if octet < start || octet >= start+size {
+		return fmt.Errorf("%d is outside of the range %d-%d of %s", octet, start, start+size-1, origin)
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package models
+
+import "testing"
+
+func TestValidatePTRLabel(t *testing.T) {
+	tests := []struct {
+		origin string
+		label  string
+		valid  bool
+	}{
+		{"2.0.192.in-addr.arpa", "1", true},
+		{"2.0.192.in-addr.arpa", "255", true},
+		{"0.192.in-addr.arpa", "1.2", true},
+		{"2.0.192.in-addr.arpa", "@", false},
+		{"2.0.192.in-addr.arpa", "256", false},
+		{"2.0.192.in-addr.arpa", "01", false},
+		{"2.0.192.in-addr.arpa", "host", false},
+		{"2.0.192.in-addr.arpa", "1.1", false},
+		{"128/27.2.0.192.in-addr.arpa", "130", true},
+		{"128/27.2.0.192.in-addr.arpa", "160", false},
+		{"8.b.d.0.1.0.0.2.ip6.arpa", "1.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0", true},
+		{"8.b.d.0.1.0.0.2.ip6.arpa", "1.0.0.0", false},
+		{"8.b.d.0.1.0.0.2.ip6.arpa", "g.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0", false},
+		{"example.com", "anything", true},
+	}
+	for i, test := range tests {
+		rc := &RecordConfig{Type: "PTR"}
+		rc.SetLabel(test.label, test.origin)
+		rc.SetTarget("host.example.com.")
+		err := ValidatePTRLabel(rc, test.origin)
+		if test.valid != (err == nil) {
+			t.Errorf("%v: expected valid=%v got (%v) (%s in %s)", i, test.valid, err, test.label, test.origin)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package models
+
+import "testing"
+
+func TestSetTargetRPString(t *testing.T) {
+	tests := []struct {
+		given     string
+		mbox, txt string
+		valid     bool
+	}{
+		{`admin.example.com. info.example.com.`, "admin.example.com.", "info.example.com.", true},
+		{`admin info`, "admin", "info", true},
+		{`admin.example.com.`, "", "", false},
+		{`a b c`, "", "", false},
+	}
+	for i, test := range tests {
+		rc := &RecordConfig{Type: "RP"}
+		err := rc.SetTargetRPString(test.given)
+		if test.valid != (err == nil) {
+			t.Errorf("%v: expected valid=%v got (%v) (%q)", i, test.valid, err, test.given)
+			continue
+		}
+		if test.valid && (rc.GetTargetField() != test.mbox || rc.RpTxt != test.txt) {
+			t.Errorf("%v: expected mbox=%q txt=%q got mbox=%q txt=%q", i, test.mbox, test.txt, rc.GetTargetField(), rc.RpTxt)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/miekg/dns"
+)
+
+// SetTargetSVCB sets the SVCB and HTTPS fields. The params are stored in
+// presentation format, e.g. `alpn="h2,h3" ipv4hint="192.0.2.1"`.
+func (rc *RecordConfig) SetTargetSVCB(priority uint16, target string, params []dns.SVCBKeyValue) error {
+	rc.SvcPriority = priority
+	rc.SetTarget(target)
+	rc.SvcParams = svcParamsString(params)
+	if rc.Type == "" {
+		rc.Type = "SVCB"
+	}
+	if rc.Type != "SVCB" && rc.Type != "HTTPS" {
+		panic("assertion failed: SetTargetSVCB called when .Type is not SVCB or HTTPS")
+	}
+	return nil
+}
+
+// SetTargetSVCBString is like SetTargetSVCB but accepts one big string.
+// The type is SVCB unless HTTPS is already set.
+// Ex: `1 . alpn="h2,h3" ipv4hint="192.0.2.1"`
+func (rc *RecordConfig) SetTargetSVCBString(s string) error {
+	if rc.Type == "" {
+		rc.Type = "SVCB"
+	}
+	priority, target, params, err := parseSvcb(rc.Type, s)
+	if err != nil {
+		return err
+	}
+	return rc.SetTargetSVCB(priority, target, params)
+}
+
+// parseSvcb parses the presentation format of an SVCB or HTTPS record.
+func parseSvcb(rtype, s string) (uint16, string, []dns.SVCBKeyValue, error) {
+	rr, err := dns.NewRR(". " + rtype + " " + s)
+	if err != nil || rr == nil {
+		return 0, "", nil, fmt.Errorf("%s value is not valid: (%#v)", rtype, s)
+	}
+	switch v := rr.(type) {
+	case *dns.SVCB:
+		return v.Priority, v.Target, v.Value, nil
+	case *dns.HTTPS:
+		return v.Priority, v.Target, v.Value, nil
+	}
+	return 0, "", nil, fmt.Errorf("unexpected type %s for %#v", dns.TypeToString[rr.Header().Rrtype], s)
+}
+
+// svcParams returns the params of an SVCB or HTTPS record as stored by
+// SetTargetSVCB.
+func (rc *RecordConfig) svcParams() []dns.SVCBKeyValue {
+	_, _, params, err := parseSvcb(rc.Type, fmt.Sprintf("1 . %s", rc.SvcParams))
+	if err != nil {
+		panic(fmt.Errorf("assertion failed: %s params were stored in an invalid format: %w", rc.Type, err))
+	}
+	return params
+}
+
+// svcParamsString returns params in presentation format.
+func svcParamsString(params []dns.SVCBKeyValue) string {
+	parts := make([]string, len(params))
+	for i, kv := range params {
+		parts[i] = fmt.Sprintf(`%s="%s"`, kv.Key(), kv.String())
+	}
+	return strings.Join(parts, " ")
+}
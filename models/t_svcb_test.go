@@ -0,0 +1,45 @@
+package models
+
+import "testing"
+
+func TestSVCBRoundTrip(t *testing.T) {
+	tests := []struct {
+		rtype, given, want string
+	}{
+		{"HTTPS", `1 . alpn=h2,h3 ipv4hint=192.0.2.1,192.0.2.2`, `1 . alpn="h2,h3" ipv4hint="192.0.2.1,192.0.2.2"`},
+		{"HTTPS", `0 www.example.com.`, `0 www.example.com.`},
+		{"SVCB", `16 svc.example.net. port="8443" alpn="h2"`, `16 svc.example.net. port="8443" alpn="h2"`},
+	}
+	for i, test := range tests {
+		rc := &RecordConfig{Type: test.rtype}
+		rc.SetLabel("@", "example.com")
+		if err := rc.PopulateFromString(test.rtype, test.given, "example.com"); err != nil {
+			t.Fatalf("%v: %v", i, err)
+		}
+		if got := rc.GetTargetCombined(); got != test.want {
+			t.Errorf("%v: expected %q got %q", i, test.want, got)
+		}
+
+		again := &RecordConfig{Type: test.rtype}
+		if err := again.SetTargetSVCBString(rc.GetTargetCombined()); err != nil {
+			t.Fatalf("%v: %v", i, err)
+		}
+		if again.GetTargetCombined() != rc.GetTargetCombined() {
+			t.Errorf("%v: value changed in round-trip; got=%q want=%q", i, again.GetTargetCombined(), rc.GetTargetCombined())
+		}
+
+		back := RRtoRC(rc.ToRR(), "example.com")
+		if back.Type != test.rtype || back.GetTargetCombined() != test.want {
+			t.Errorf("%v: RRtoRC changed the record; got=%s %q", i, back.Type, back.GetTargetCombined())
+		}
+	}
+}
+
+func TestSVCBInvalid(t *testing.T) {
+	for i, given := range []string{``, `1`, `x .`, `1 . foo=bar`, `1 . ipv4hint=2001:db8::1`} {
+		rc := &RecordConfig{Type: "HTTPS"}
+		if err := rc.SetTargetSVCBString(given); err == nil {
+			t.Errorf("%v: expected an error for %q", i, given)
+		}
+	}
+}
@@ -91,3 +91,26 @@ func ValidateTXT(rc *RecordConfig) error {
 	}
 	return nil
 }
+
+// ValidateNoControlChars returns an error if the value of a TXT-like or
+// CAA record contains control characters. They break many consumers and
+// are rejected by some providers.
+func ValidateNoControlChars(rc *RecordConfig) error {
+	var values []string
+	switch {
+	case rc.HasFormatIdenticalToTXT():
+		values = rc.TxtStrings
+	case rc.Type == "CAA":
+		values = []string{rc.GetTargetField()}
+	default:
+		return fmt.Errorf("rc.Type=%q, expecting something identical to TXT or CAA", rc.Type)
+	}
+	for i, v := range values {
+		for _, c := range v {
+			if c < 0x20 || c == 0x7f {
+				return fmt.Errorf("%s target contains control character %q: label=%q index=%d", rc.Type, c, rc.GetLabel(), i)
+			}
+		}
+	}
+	return nil
+}
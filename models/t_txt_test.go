@@ -102,3 +102,32 @@ func Test_splitChunks(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateNoControlChars(t *testing.T) {
+	tests := []struct {
+		rtype string
+		d1    []string
+		e1    bool
+	}{
+		{"TXT", []string{`v=spf1 -all`}, true},
+		{"TXT", []string{`foo "bar"`, `baz\010`}, true}, // escaped, not a control char
+		{"TXT", []string{"foo\nbar"}, false},
+		{"TXT", []string{"foo", "bar\x00"}, false},
+		{"SPF", []string{"v=spf1\t-all"}, false},
+		{"CAA", []string{"letsencrypt.org"}, true},
+		{"CAA", []string{"letsencrypt.org\r"}, false},
+		{"CAA", []string{"letsencrypt.org\x7f"}, false},
+	}
+	for i, test := range tests {
+		rc := &RecordConfig{Type: test.rtype}
+		if test.rtype == "CAA" {
+			rc.SetTargetCAA(0, "issue", test.d1[0])
+		} else {
+			rc.SetTargetTXTs(test.d1)
+		}
+		r := ValidateNoControlChars(rc)
+		if test.e1 != (r == nil) {
+			t.Errorf("%v: expected valid=%v got (%v) (%q)", i, test.e1, r, test.d1)
+		}
+	}
+}
@@ -38,6 +38,19 @@ func (rc *RecordConfig) GetTargetField() string {
 // 	return rc.Target
 // }
 
+// GetTargetCombinedFunc is like GetTargetCombined, but the strings of TXT
+// and SPF records are combined by txtFn.
+func (rc *RecordConfig) GetTargetCombinedFunc(txtFn func(txts []string) string) string {
+	if txtFn == nil || !rc.HasFormatIdenticalToTXT() {
+		return rc.GetTargetCombined()
+	}
+	txts := rc.TxtStrings
+	if len(txts) == 0 {
+		txts = []string{rc.GetTargetField()}
+	}
+	return txtFn(txts)
+}
+
 // GetTargetIP returns the net.IP stored in Target.
 func (rc *RecordConfig) GetTargetIP() net.IP {
 	if rc.Type != "A" && rc.Type != "AAAA" {
@@ -93,14 +106,22 @@ func (rc *RecordConfig) GetTargetDebug() string {
 		// Nothing special.
 	case "DS":
 		content += fmt.Sprintf(" ds_algorithm=%d ds_keytag=%d ds_digesttype=%d ds_digest=%s", rc.DsAlgorithm, rc.DsKeyTag, rc.DsDigestType, rc.DsDigest)
+	case "HINFO":
+		content += fmt.Sprintf(" hinfoos=%s", rc.HinfoOS)
+	case "LOC":
+		content += fmt.Sprintf(" locversion=%d locsize=%d lochorizpre=%d locvertpre=%d loclatitude=%d loclongitude=%d localtitude=%d", rc.LocVersion, rc.LocSize, rc.LocHorizPre, rc.LocVertPre, rc.LocLatitude, rc.LocLongitude, rc.LocAltitude)
 	case "NAPTR":
 		content += fmt.Sprintf(" naptrorder=%d naptrpreference=%d naptrflags=%s naptrservice=%s naptrregexp=%s", rc.NaptrOrder, rc.NaptrPreference, rc.NaptrFlags, rc.NaptrService, rc.NaptrRegexp)
 	case "MX":
 		content += fmt.Sprintf(" pref=%d", rc.MxPreference)
+	case "RP":
+		content += fmt.Sprintf(" rptxt=%s", rc.RpTxt)
 	case "SOA":
 		content = fmt.Sprintf("%s ns=%v mbox=%v serial=%v refresh=%v retry=%v expire=%v minttl=%v", rc.Type, rc.Target, rc.SoaMbox, rc.SoaSerial, rc.SoaRefresh, rc.SoaRetry, rc.SoaExpire, rc.SoaMinttl)
 	case "SRV":
 		content += fmt.Sprintf(" srvpriority=%d srvweight=%d srvport=%d", rc.SrvPriority, rc.SrvWeight, rc.SrvPort)
+	case "SVCB", "HTTPS":
+		content += fmt.Sprintf(" svcpriority=%d svcparams=%s", rc.SvcPriority, rc.SvcParams)
 	case "SSHFP":
 		content += fmt.Sprintf(" sshfpalgorithm=%d sshfpfingerprint=%d", rc.SshfpAlgorithm, rc.SshfpFingerprint)
 	case "TLSA":
@@ -3,11 +3,13 @@ package diff
 import (
 	"fmt"
 	"sort"
+	"strings"
 
 	"github.com/gobwas/glob"
 
 	"github.com/StackExchange/dnscontrol/v3/models"
 	"github.com/StackExchange/dnscontrol/v3/pkg/printer"
+	"github.com/StackExchange/dnscontrol/v3/pkg/txtutil"
 )
 
 // Correlation stores a difference between two domains.
@@ -45,9 +47,19 @@ func New(dc *models.DomainConfig, extraValues ...func(*models.RecordConfig) map[
 	}
 }
 
+// NewDecodingTXT is like New, but TXT records are compared by their
+// decoded content. Records whose strings only differ in how they are
+// quoted, split or separated by whitespace are equal.
+func NewDecodingTXT(dc *models.DomainConfig, extraValues ...func(*models.RecordConfig) map[string]string) Differ {
+	d := New(dc, extraValues...).(*differ)
+	d.decodeTXT = true
+	return d
+}
+
 type differ struct {
 	dc          *models.DomainConfig
 	extraValues []func(*models.RecordConfig) map[string]string
+	decodeTXT   bool
 
 	compiledIgnoredNames   []glob.Glob
 	compiledIgnoredTargets []glob.Glob
@@ -60,6 +72,10 @@ func (d *differ) content(r *models.RecordConfig) string {
 	// its output with r.GetTargetDiffable() to make sure the same
 	// results are generated.  Once we have confidence, this function will go away.
 	content := fmt.Sprintf("%v ttl=%d", r.GetTargetCombined(), r.TTL)
+	decoded := d.decodeTXT && r.Type == "TXT"
+	if decoded {
+		content = fmt.Sprintf("%q ttl=%d", decodeTXT(r), r.TTL)
+	}
 	if r.Type == "SOA" {
 		content = fmt.Sprintf("%s %v %d %d %d %d ttl=%d", r.Target, r.SoaMbox, r.SoaRefresh, r.SoaRetry, r.SoaExpire, r.SoaMinttl, r.TTL) // SoaSerial is not used in comparison
 	}
@@ -80,13 +96,32 @@ func (d *differ) content(r *models.RecordConfig) string {
 		}
 	}
 	control := r.ToDiffable(allMaps...)
-	if control != content {
+	if !decoded && control != content {
 		fmt.Printf("CONTROL=%q CONTENT=%q\n", control, content)
 		panic("OOPS! control != content")
 	}
 	return content
 }
 
+// decodeTXT returns the content of a TXT record as one string. Each of
+// its strings may itself be a list of quoted strings, as some providers
+// return them.
+func decodeTXT(r *models.RecordConfig) string {
+	txts := r.TxtStrings
+	if len(txts) == 0 {
+		txts = []string{r.GetTargetField()}
+	}
+	var b strings.Builder
+	for _, txt := range txts {
+		if parts, err := txtutil.ParseQuoted(txt); err == nil {
+			b.WriteString(strings.Join(parts, ""))
+		} else {
+			b.WriteString(txt)
+		}
+	}
+	return b.String()
+}
+
 func (d *differ) IncrementalDiff(existing []*models.RecordConfig) (unchanged, create, toDelete, modify Changeset, err error) {
 	unchanged = Changeset{}
 	create = Changeset{}
@@ -343,6 +378,34 @@ func sortedKeys(m map[string]*models.RecordConfig) []string {
 	return s
 }
 
+// IgnoredDesired returns the desired records of dc that match one of
+// its IGNORE_NAME or IGNORE_TARGET rules, mapped to a description of the
+// rule. IncrementalDiff refuses to manage such records; providers can use
+// this to tell the user which rule each of them matches.
+func IgnoredDesired(dc *models.DomainConfig) map[*models.RecordConfig]string {
+	names := compileIgnoredNames(dc.IgnoredNames)
+	targets := compileIgnoredTargets(dc.IgnoredTargets)
+	ignored := map[*models.RecordConfig]string{}
+	for _, rec := range dc.Records {
+		for i, g := range names {
+			if g.Match(rec.GetLabel()) {
+				ignored[rec] = fmt.Sprintf("IGNORE_NAME(%q)", dc.IgnoredNames[i])
+				break
+			}
+		}
+		if _, ok := ignored[rec]; ok || rec.Type != "CNAME" {
+			continue
+		}
+		for i, g := range targets {
+			if g.Match(rec.GetTargetField()) {
+				ignored[rec] = fmt.Sprintf("IGNORE_TARGET(%q, %q)", dc.IgnoredTargets[i].Pattern, dc.IgnoredTargets[i].Type)
+				break
+			}
+		}
+	}
+	return ignored
+}
+
 func compileIgnoredNames(ignoredNames []string) []glob.Glob {
 	result := make([]glob.Glob, 0, len(ignoredNames))
 
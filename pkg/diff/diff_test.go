@@ -350,3 +350,58 @@ func TestCaas(t *testing.T) {
 
 	checkLengthsFull(t, existing, desired, 3, 0, 0, 0, false, nil, nil)
 }
+
+func TestDecodingTXT(t *testing.T) {
+	existing := []*models.RecordConfig{
+		myRecord("spf TXT 1 x"),
+		myRecord("dkim TXT 1 x"),
+		myRecord("other TXT 1 x"),
+	}
+	desired := []*models.RecordConfig{
+		myRecord("spf TXT 1 x"),
+		myRecord("dkim TXT 1 x"),
+		myRecord("other TXT 1 x"),
+	}
+	existing[0].SetTargetTXT(`"v=spf1 -all"`)
+	desired[0].SetTargetTXT("v=spf1 -all")
+	existing[1].SetTargetTXT(`"v=DKIM1; k=rsa; "   "p=MIGf\"MA0"`)
+	desired[1].SetTargetTXTs([]string{"v=DKIM1; k=rsa; p=", `MIGf"MA0`})
+	existing[2].SetTargetTXT("hello")
+	desired[2].SetTargetTXT("hello world")
+
+	dc := &models.DomainConfig{Name: "example.com", Records: desired}
+	un, _, _, mod, err := NewDecodingTXT(dc).IncrementalDiff(existing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(un) != 2 || len(mod) != 1 || mod[0].Desired.GetLabel() != "other" {
+		t.Errorf("expected only the different content to be modified; got unchanged=%v modified=%v", un, mod)
+	}
+
+	// The default compares the strings as they are.
+	checkLengths(t, existing, desired, 0, 0, 0, 3)
+}
+
+func TestIgnoredDesired(t *testing.T) {
+	dc := &models.DomainConfig{
+		Name: "example.com",
+		Records: []*models.RecordConfig{
+			myRecord("www1 MX 1 1.1.1.1"),
+			myRecord("foo.www2 MX 1 1.1.1.1"),
+			myRecord("www3 CNAME 1 ignoreme.com"),
+			myRecord("www4 MX 1 1.1.1.1"),
+		},
+		IgnoredNames:   []string{"www1", "*.www2"},
+		IgnoredTargets: []*models.IgnoreTarget{{Pattern: "ignoreme.com", Type: "CNAME"}},
+	}
+	ignored := IgnoredDesired(dc)
+	if len(ignored) != 3 {
+		t.Fatalf("Got %d ignored records, but expected 3: %v", len(ignored), ignored)
+	}
+	if rule := ignored[dc.Records[1]]; rule != `IGNORE_NAME("*.www2")` {
+		t.Errorf("Got rule %s", rule)
+	}
+	if rule := ignored[dc.Records[2]]; rule != `IGNORE_TARGET("ignoreme.com", "CNAME")` {
+		t.Errorf("Got rule %s", rule)
+	}
+}
@@ -332,6 +332,37 @@ var DS = recordBuilder("DS", {
     },
 });
 
+// DHCID(name,digest, recordModifiers...)
+var DHCID = recordBuilder('DHCID');
+
+// HINFO(name,cpu,os, recordModifiers...)
+var HINFO = recordBuilder('HINFO', {
+    args: [
+        ['name', _.isString],
+        ['cpu', _.isString],
+        ['os', _.isString],
+    ],
+    transform: function(record, args, modifiers) {
+        record.name = args.name;
+        record.target = args.cpu;
+        record.hinfoos = args.os;
+    },
+});
+
+// RP(name,mbox,txt, recordModifiers...)
+var RP = recordBuilder('RP', {
+    args: [
+        ['name', _.isString],
+        ['mbox', _.isString],
+        ['txt', _.isString],
+    ],
+    transform: function(record, args, modifiers) {
+        record.name = args.name;
+        record.target = args.mbox;
+        record.rptxt = args.txt;
+    },
+});
+
 // PTR(name,target, recordModifiers...)
 var PTR = recordBuilder('PTR');
 
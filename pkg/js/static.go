@@ -212,135 +212,137 @@ var _escData = map[string]*_escFile{
 	"/helpers.js": {
 		name:    "helpers.js",
 		local:   "pkg/js/helpers.js",
-		size:    28655,
+		size:    29427,
 		modtime: 0,
 		compressed: `
-H4sIAAAAAAAC/+x9WXfbONLou39Fxed+oZQw9JJ25jvyaO6ovXT7jLcjyT2Zz9dXA4uQhIQiOQBoRd1x
-//Z7sBLgIjs+vbzcPHSLQKFQKBQKVYUCHBQMA+OUTHlwuLW1swNnM1hnBeCYcOALwmBGEhzKsmXBONAi
-hX/PM5jjFFPE8b+BZ4CX9ziW4AKFaAEkBb7AwLKCTjFMsxhHLn5EMSwweiDJGmJ8X8znJJ2rDgVsKBtv
-v4vxwzbMEjSHFUkS0Z5iFJeEQUwonvJkDSRlXFRlMyiYwoUhK3hecMhmoqVHdQT/yoogSYBxkiSQYkF/
-1jC6ezzLKBbtBdnTbLmUjMEwXaB0jlm0tfWAKEyzdAZ9+GULAIDiOWGcIsp6cHsXyrI4ZZOcZg8kxl5x
-tkQkrRVMUrTEuvTxUHUR4xkqEj6gcwZ9uL073NqaFemUkywFkhJOUEJ+xp2uJsKjqI2qDZQ1Uvd4qIis
-kfIoJ3eIeUFTBigFRClai9nQOGC1INMFrDDFmhJMcQwsg5kYW0HFnNEi5WQpuX21SsEOb5YJDi9zxMk9
-SQhfCzFgWcogo0BmwLIlhhitgeV4SlACOc2mmEk5WGVFEsO96PU/BaE4jkq2zTE/ytIZmRcUx8eKUMtA
-Kgcj+Ri5syIHa1Fc4tXQMLYj6kPg6xyHsMQcGVRkBh1R2nWmQ3xDvw/BxeDyZnAeKM4+yv+K6aZ4LqYP
-BM4elJh7Dv6e/K+ZFUlpOctRXrBFh+J599Adj8BUG8Jxyq61CDw5iGymeu0L4rP7T3jKA3j9GgKST6ZZ
-+oApI1nKAqEC3Pbin/iOfDjoi+ldIj7hvNNQ360yJmb5SxjjibniTczyp3iT4pWSC80Wy96KlJRDdMiy
-Zay4VxLUgyAI6yuyV/4MPV714JdHF36a0bi+fK/L1euC61U6Hp/3YDf0CGSYPtRWO5mnGcWxq3uqVRzR
-Oea+QnDZpdfdMaJz1lmGevEbXom9IaOA0XQByywmM4JpKOSKcCAMUBRFFk5j7MEUJYkAWBG+0PgMkNQx
-PdOpYE9BGXnAydpAKPEU0kDnWHaT8kxyNkYcWbGeRISd6h47y64nsR09Bi2GgBOGbaOBoKDSQgyxIwT1
-k1wBbpX457Po9tOd5dKhhXts6utKjqXS2STCXzhOY01lJIYWwtKn1lE6C5qtIPjnYHh5dvlDT/dsJ0Mp
-pSJlRZ5nlOO4BwG89cg3GqBSHMCxEfBKjSZMLS01OLVZHKslVa6oHhxRjDgGBMeXI40wghuG5YabI4qW
-mGPKADGzFgClsSCfOVr9uG2tSu2hRtzfsLIVmXYaCfRh9xAI/NXd96IEp3O+OATy9q07Id70OvC3pDrR
-j/Vu9lU3iM6LJU55aycCfgn9EvCW3B02k7Bs7FXIVG1ji0ga4y9XM8mQLrzq9+HdXrcmPaIW3kIglmyM
-pwkS+/gyo2KWUApZOsXeZub0Y/SuS1CdDAkjaTB2xfHk5OP45FJNbLcHN3lclRNAiTAN14DiGMdKWxx3
-uqGwEKz6FXJEcTZzZMXD3CQnkznmqgu9ADVlho0GsA9pkSQb2LVCDNKMlzxbYy7FVxIlrEyYolRA3GMo
-5AhjJf3Hna62QyOPs3ppZfefonKIfdmjKGCcdnZD9akE6Z3TwimGd7DXJPV7v6M4Chq6bWJyq2FIfAd9
-p8Gh0OkJ5gGD7AHTFSVc6Qal5yMtLs1T1oOxcBvIMk+wpFK2NBoQ8emCpHPRHCXzjBK+WELBcAz361JK
-uhEcoTQmUvxkG8ykL4NSwF/QlKtCgSWbOfgDpg0VZa9KmRA7nmBOjl0JVc0EAq9lBOMFhiQTLofuRCBQ
-1odn0zYPvlEDFklyWCk+x6lUd60q0FvNG+RBuGiXYph9f2bJ3e22oGjbkRDl3TBhnI+K2Yx8gT5sR9vw
-1mLxYWdZkZaQrri/89Bo+pyNVTmg0n0krDJpYm6ky6oQ69k1NolZ7nLqhOlrB/j1q09Qv+8PpmoAODTY
-eURqaqkuUYq0oDAtKMWp0Ahm1l16rFWuSTHL+W/lZFY7L9WGmulK08MWYGlwk7gHJBRrrVedU2Np+waM
-Y8q4trJqZnX7yeng5nw8Am2cC2YwzKXrqLbPUq8IFx3lebKWP5IEZgUvqFlkLBL4ToR1KY1GnpXIV8LL
-nyYYUUDpGnKKH0hWMHhASYGZ6NA1IHQr6wrW/d225fGkrnRNCLnRuUqz61tI4/F556HbgxFWIYfx+Fx2
-qvY9ZQE5ZCtwx1sTVuOIC8+68+BZjQ/Ql1GfdD7OjguKpN374KljPVcGeYe67WnEeQJ9eDhscgIaMDvq
-x2jNPjxE8ndn5/92/k/8ttu5ZctFvErXd/+7+792nB3WtmjbYh+MOSI2TyTmlMQQ6941Od7GWaSEQx8C
-FtR6ud2/czvQkGWl541CX1ilDJ+l3LbfM7MoBlvIhcN6sBfCsgcfdkNY9OD9h91ds2KK2yAOxC5XRAt4
-A/vf2eKVLo7hDfzFlqZO6ftdW7x2iz8caArgTR+KWzGGO8/PfbCLz7qInqCZhWcErtzI3FXitv2dpC72
-lk5UerStwrdEn/HRYHCaoHlHLu6Ko14KtFw+nlSrBTVFSEYcv/aVdnC72dmBo8FgcjQ8G58dDc6Fx0I4
-maJEFMtApQzVuTBSekqa9uCvf4W/dFWw1Q27bJvghFDH2yHsdgVEyo6yIpXacBeWGKUM4iwNuDBNxIZl
-QmlSqzmefeQ2FsvCYNdIRHOUJO501kJAunlD/McgliGgIo3xjKQ4DlxmWhB4t/ctM+xEM24FGUKsNa7K
-RAwUmSQP9cxdaC9W7NldOQ8D6Ou67wuSiJEFg0DzfjAYPAfDYNCEZDAo8ZyfDUYKkYqObEAmQBuwiWKL
-7n9uhicTB6mOaj2Ju2zX0ENZGYSa38Ic78Gt5f1tILoLQijXrxMAug0EGUGolCviePBzQfEgIYiN1zn2
-ISWpTZj0/zhFKZtldNmrLsdQkhXagETD8lQGmIRzggoOgOregKivQ8+Gc6Ipug0So5kgMZxu1WSqg2hm
-3Nk+1rlDRi3o0oxE7gwqbmmRuGaUNpzCrceuG+lv5r+v6sQYX7lqWFb6vFSrECUMN6zO22AQhKDEPITg
-6HJwcRLc2fiA7kwFCGzs/+C9L7ZaYJX4tomtbVUXWlv1W4ns8OD97y6w7I+SWHrwfrO8WoCXS6tF8W2y
-qoXhf64uTzo/ZymekLhbCnCtqm1/dsdV5cGm4bsj133IwevfTw29Mmrdqmd+NAzbN0CapO03Xp6dUnb9
-IOzAOVxQBXIF+2VqNVcL63AXH6sl44/jatH1eFgtGl2f1oqGP1WLLgd+0xbtIuu7ju1ldtp5KOHaNctR
-08Yth1meRoyvjq86PCHLbg/OOLCFOStEKWBKVbBG9mO8i11hdO3t/3f0MoWE5u2Vsp8/TwlNEeJoXiqh
-+RNqyrWNFYGm+8tieY9pA5XeKqhb3Kxqcpf6RMrs84wsCdow81Lqjd1tNqnPeC1EqQz5hRCTOWZq01I/
-Fdrj+g61fTzafunWpDrW9YphXr0lqB1EUaf3uI0wPhl/oEzFTI3TAKmvBrAy5KohbUEDcDlwA12WtIL7
-oN+wBTtSeD0ePk8Gr8fDugQKfacRSeWnUGU0xjTMKZ5hitMpDuVKCIUbR6bydAx/yZ/sUCKsd6mV7Atl
-VJLWLlslze0wcjDtPehRtgOo4W9SqH+u5ZainFPJJwMmP5rhSoYZ4LKkuYXSihpYfjTDaT4aSP3ZDKtY
-akDV18uWw2j4k5LhnBKxWNfhCpP5god5RvmTIjsa/lQXWGkovFBcDRXt0qjI2yDRGd1Q+2fLGqMPZoil
-/KjvJlg1WAOpvhpxZtRCid8vlIXRj6fXShrKvVTuok+YabJhgyCI4heLwjN2zxlJ55jmlKQbpvxPNskY
-W8zyb9gaJbwzMKs5yqJvMurM5CpbqWBojkNgOMFTntHQnpkqY2mKKSczMkUcy4kdn48aDHBR+uJplRS0
-z5ahrB3CpfgbFzrI3FNnLDJnlAGCbQW/bc9+/sjIQcKQ5IqBkh+NYIY75SahvhuBXUaZBm7ZC5REmauq
-eXpFVfbUl0oEwPGMv3Th61coE62+KE9Qxklvxlej6/OzsTo+LTOYFojLZGBaTPUR/w/ZuwQ/4ERmFgPP
-RHOWJybBefxxrEcRMB21Umli00WRfmaQzWD/4CBSUVbbq4yIfOEjgWdgVmQPgmWRcKKPnOBRJizorKb9
-g4N392uONd6tnR25TD6OL27Ox2ej68HRSStWlqMpNvhkLWQpyFK4FX6pzWrA8Z06O/w4fp6tKoZfX6bC
-039p1M0sn8pE/zGqU/CHq2QkrE+bGPAVmeKeCwNgRJYoIZkRyrhuUAX8wg0iDUzSmDyQuECJ6SLy21xe
-jU966pgfUywzRMoMqT3dKLSHMsyEHrI0WQOaTjFjrUSEwBcFA8IhzjBLA5kYwDGFlRD9lRi16IqkZogV
-2n7MVvgB0xDu1xLUJMu7HFB0hzJjcimoxAzu0fTzCtG4Qpmfl71aYJX4n+C0I/Mzu9Dvw55MdOqQlONU
-TDVKknUX7ilGnyvo7mn2GacOZzCiMr1fM57juT7X5ZhxFtVChFp1OHqoLUK6OezqApYC0IdbB/rueXHU
-po5ud++e7quRsFqw9eJjxQx/aslffKyv+IuPv6Ph/WebzssvTb5Xi+38LHv38plHfpcNBxuXozIOcHEy
-Ohn+dOLFFZxgeQXAjSBXM03gVR8asjWDEkWpXXLOIEuxtVjkIb/Mowq+4azWPW6WqSxuTj48divntSUh
-k7bEFodWnd8bNfFi8nvkHPwCKZtwnvTgIeKZRtatRvfLqwpWZCcc3SfYyXEfyyO02yRbybyPBZkverAf
-QopX3yOGe/D+LgRV/Z2pPpDVZ9c9+HB3ZxBJK2R7D36FffgV3sOvh/Ad/AoH8CvAr/Bh26aZJCTFT2Um
-VejdlLtHcuhX4b2UTgEkyYU+kDySP/0DK1lU1bt+1rwCaUpQM6gn0RLlCi4spZA0NXEvcRTL/TjjHdKt
-Z7M9dqNPGUk7QRhUahv1t0uMQavI3pzu5vBIzLjlkvio8UkUPskpCdTCK92F5Zb4/lP5pQlyOCbJfx7P
-hNLqw62lKo+SbNUNwSkQS6Zr15NeOY54yuWgrz9lKz0C+BWCbtPCV9Aa6BACe9p09sPl1VCdOjgq2S0t
-13yMc4qF7xuHMrdGQU2EznL7cor9DPdaRbVDp6rlwLSinb3bPF5OvaeVNfbxYPjDybhT24CaqkOgY+cy
-2zPp0FeH9E6RS5M17XlpAj2F2N85JJEX11fD8WQ8HFyOTq+GF0r5JlKbK/VkbznIXbcKX9+DqxBV4+c2
-qHURCK0d6Kxs+ZvzxLd5fktrJvh78IRpYvJoq8YO5kiTX6pveQJebl7KtKmOsFvvUKZ5Kmie1A9EboY/
-nHQccVEFVgLi6B8Y5zfp5zRbpYIAdaCt7YGrSa29LWtFwWlhMQhv/PhyNDo5ksRguiSc49gk9SKKe6Ji
-exvgOJPHt5Lva+UbYs6Fp9NxEh5lyt12lm4DwEkqWOL0oTMhCTO30CTsbCawE/YUsB1iCTO5ujTjjCNU
-8GwSp4zhKfQlDWKUja1OT9ubzWZt7UybaZayTOz/2VzlEWzb22AO+fJuj1FpEZxxdQC+AgRp9i7LI4Dr
-BAs9L7SdNybIaIVcdXnBJJUSmca9RJ8xpJleCVMphSxSVzSWmMmYlkzajglDeY6FWZICMhnfFMveI2ED
-aSX65s0WvIG/l2RvwZsd766vNc87ahUyjij3cpOzuNWMksA2ybs1v1veRTOJ3V5Ot6MrBZBL9FCuNnX7
-7l6pKDkWeeUNflEG7KOqd2CbYLKcs0h2fXe7ewcDY+ELreLCG770/SZ7d3CVKw/dZLJkdFM7q2fAXKAs
-k/S9vH2Trg5vDKvGQgRaE/8Qc5LpYZCuS6WpBOMeO7hEhwTH+pqUfiBAExQ5uR3LgiN9Z2hOHnDqktXK
-GjEYIzsNwyzp4pnErHD64ufvPypkLrAb2RG/pRGnlwnr/PKoIEJHuuzu1OCRl3622IdKN/Blm5G2axSk
-YvgCPWBnsPbCnWJ9taXAbSYKUKqvaMk15dzk1KnDTZGQdq/etZDVzrsx3NO0gRpr0m33TAP32dEjx8J1
-5sOTpoY5aZ2NJqfOArepI++KXhZDv2wiPboaYP06dBZ32zyIZRabPPoG36H5+vIGdDs7oC7+81Jq5aLS
-EbHGRvLuRhY7iuj1a+fIwKtq7VkPxkHivUrg4ThsxPDYWGqvZzu2mZzidn41E6iDOSfD4dWwB8Yc8u5t
-Bw0o2+VReXdaAKomfDUgIC+5xPr60y+PfiCg1Aj6VRJ3ZmpRqr+W2425nlcZssBpm50Tmbpj29SGKJ3e
-0tflePmEuytAasFXxY06cu38QtX7VdMh9+O3tVaB0Zr6xRFWuxNvFL7LhkZE5Q7aacLhs6kBQTeCqzRZ
-w8bGmwiQ77WwQqn4oBqxFgx1A9Nb3kpOEqHwbTdbmxRZlRuNikxLxrHYM4jcVR3J8AJUBlrlbrbdTHaE
-tMRZXqLca5IksScWaWkbyednioYt0Gb6ethv9+4a8n2fLVo1EQs2APkd795txGdDwXpkMtiJSFKb9U16
-RV73trritkqA8EGdDIN2mbEqpVlmGoTlOVcv3RzV9suXFao2RjfKt3rkZPQbptR5maZWV3/hxbbiSc+7
-7+aDPFY27rqZ2mBOHNab2E3Ngpez5zetWnc/ojROsHMxXr24YO+xs/ot5dh5pOD161azSgj+qz4ER6eT
-4cnx2fDkaBw8E358cnFdNmpaYLP/xEJp3Dq0hPok404p++1ou7vV1pn7yoLzddi48D0zVsZz2nemb8Ne
-N5I3gjuGmBz/q77X+vXrGi9lqurvROzbPgRRAG+foLmiYfwnZSJzOqSfuGqwQPW6VXXOyvbCn0+EDFAc
-K2+7E5t7TP7dJuHHO0FgMoMyqSCVjkkIiLFiiYHkAh3FjEXWyCX6aL7iyzS4MTW/xXNZ3EfDpp4WatI+
-TQ9UKXQ2Grv1DD1kzk+9t6V8jaaZ3fzsU4ynJMZwjxiOQbjTglQD/8662eYBKKYUTOleA1K5GF7WlWx6
-1fjok4D1Hn6SsOauwtkpXHwsMaspk/NoxrnlOBus8b0n3y970pJZKmes2STZ8CJV+TIVxdNmp3Xjk1Ev
-9rbk4Fv9rGd4Wcs2/2qjd1X3rFyvqvLi1TeCtfpctShpzWKyUdOL1sezgrDZwtNPaDXXBp3RZ5LnJJ2/
-6gY1iO5z3tmo60f/mTuKpyaETnIo39qzVg6DGc2WsOA87+3sMI6mn7MHTGdJtoqm2XIH7fz33u7BX77b
-3dnb3/vwYVdgeiDINPiEHhCbUpLzCN1nBZdtEnJPEV3v3Cck13IXLfjSOWq67sSZF46N5eM/PJLJep0g
-Ml7Yzg7kFHNOMH2njpe823Hy39v4dveuC29g/+BDF96CKNi761ZK9msl7++6lRcAzSlmsXQzDtJiKa+/
-29vvDff3gqD65paTpyDwNbRJi2XtwUOl9+G/BJ0Nken3Quf8Taqed++8O/iCRrhAfBHNkiyjkugdOdpS
-jAT2jkUv2KC354a4dWwv4iVZEc8S+fJRQhDDrKdSkTBH5mSFSSqdVDmb0iGvaZ1OrodXH/81uTo9lWmP
-U4tyktPsy7oHQTabmZzHa1EkzwLuExxXUVy2Ykh9BDhtan96c37ehmFWJImH4+0QkWRepCUudfb0zrwk
-5bJAnj9p2vXxRzabqe0w5cQ+XeOfQvV88vRzNK2cmuh2Jccaek3rnbZ1c/lkL6np5CYlQnegZDQ6bx6Z
-7eTm8uynk+FocD4anTcNpTCoGEv8kfidpM/u4/KpLtQwpDzfjMZXFyFcD69+Ojs+GcLo+uTo7PTsCIYn
-R1fDYxj/6/pk5GiFibnmW66EIVaPEf/Gl31lA3s5NgiDrtQ7+uK9HrhxehruPTpuVHuCn3qmOQg3jcu/
-WIgZJ6kMEzyr1R97Mq5fnX4LQShUmTotLyn2z7E1Cz3nsZGPvnv5/5nZxsyb4XmdfzfDc7F96/r3u3uN
-IO939wzU6bDxHq8sNjCXo73JzfD89J/HTVmWps5kW46uTyff35ydi/XN0WfMymMpqadzRDnrybNq+dM8
-4Te6PjWeQYdncI/hUyZ2fOWRBBB05R6QoHucqObHlyP1aV9PyilZIrp2cEXQKTXq3wOZekDRqgf/lCnj
-HfVetsTSVVZ5pt4ZLFKUqMezjdnm0Gk2HkmR9N4EPZwssSRFeHAqiRpT+TKmVEouKeqFSmnRhPol9fKh
-p669OqHx4mWeIK5wozgm+uTYPM6quDWV9x9id7wTls/+K1aDniWIc5z2YAAJYdx9M1y11wB6qxWG6AKj
-eK8Hg2UmX3eH7ftiNsMUaJYtt9Vhs0xMlX6lTW0nHC/tu/T5DKYL+aCVYNQXfoG+jMjPWI1rib6QZbEE
-Rn7Gpe86/ji2DPtJpZgIYmD/4EAddFLMZIJDCvIWSJ6UNxCcse8fHARdZytxxLJh61DqX8nj16/gfJYn
-KvsNab+usNtzCMQhwYhx2AesH8Gsmai6Ry147jmQLXbVRq0hRSvhGZYfr/p9CII6KlHXh2BC0YrlM4tO
-7X3qLElm0y6wlQtHrtTuqOInuTqVMtDCAnOOmMXawdyIgrS2yis/CoEiwUSnNXt1RmDQtYjLlecvta3y
-WUctq2LZyOc5/1NgJpMCzV8UAOT07sQ00KqC1LBVkaTxlpzVBeVpxa73CrFt0K/AN6Rz7uyoQyIUx5YW
-wQ5No3mfOw24fBdjmfN19aJMSWjzjEsm55XDQ1UY1e47Calwr1E5l54EeSbENpM38HBcjzQrSjhPGjMB
-lFM8/jguKQ61BIRA81C9o2hRdJ+dF/AE4u6TvrsjR8bdFlIk/6jBjAgpUj6HUsFCTqpiYpr5sqAuuxlJ
-MDDegvNRSP3q47DFHh5Z0oKoVKo+prLcoiqLPFy/hWwYnv6wef35OqPK1ooo1WZaasVyrltlqCY7T2Iq
-M5a9AI77GOEmk2ajTXI0GGywRUgW45lqOs1Srp7JJUkZxe5kOlGsBJ9M9XOIPfg+yxKMUnk8itNY/nUP
-LO+aa71IKI53DHwkZF6YHjZ45l0odl7moXhWMBzXumeswD041xvF0cD8wREVokiylfoDLxLORc0qD1xC
-R5kr6oKMFhNjAihDT+JYkSTuwUBjLvubijHLTgTEFNG4qTebFxpt7s8xE5ypbjUTnr9pVwRcUWw3F/Up
-tHiapTjo+sVwGxwGd4dNKMSYK2hkUTMqVWXQWXyWejMsS92rSuMufP1aQvvAlXi7rTI7Zr8PuxvA9Eg2
-VbuYVO5Igx3mrtC6HSbmHKecrkWRojyjpYC91CiqTo1Ym9Xn1Jwqu2zrb6lJ9XQ0GPjqKZDNghAcJKH3
-6qm72bW8s/Z81N36n8ZoFOBuy5lMCIljCblSoE5rEpyqU5pnUigQlBSKr1ty1+0ebrUtiW8gzBGslxMn
-ZSesonWJrG4kagtFcPyPswtzB9j+YZa/7R98B/drjr2/svGPs4sOovaZPnmrXe/q+wcH5RvIw9aLaWb4
-iNKGIcPbfom0HP3QZG7QiCVkijskFLAOqH/YMTRDtIm7K4ryHFNJzDzJ7jtd+dP58zGQZEhuWTOSYOVL
-D1jpPlgedEgKP2RdwSOiH2zPUk6zBFC6XqF1KB8pF+30lQR7G9wkzzKUEr5+N13g6Wft4F5mHPcMYYTp
-W5updNup8K6LNM6mhbrsDwucyLHYXOdRJlPy1QsBa0FTtkqBEvY5crORpSaa6F5sJEsnw+zfQR+2P7Ht
-Q314O8VCvUhKSDpNihhD9IkZ9th3+cUn9CXtKh2lkxZJEpaY3b8y4RyXKjwt56Wa1o4Eakmol3VGlDG3
-YW/NdtHf0fmZIJIIA5o52+r52cS+925yr033Vlw/Y3kFvVpfeRZZ7Ou3n/H6TkZot+3R0HZVrzqAFqf8
-rqk59yTq9GR89GP1z5PNMJ8uWpgdTeX76teDy7Mjear1/wIAAP//ehx7ze9vAAA=
+H4sIAAAAAAAC/+x9aXMbObLgd/2KtGJfF2mXS4dbnhfUcHbYOroVoytIqsfztFo+iAWSsItAPQAlit2t
+/u0buKpQFyUr+pgPqw82CSQSmYlEIpFIgEEmMAjJyVQGh1tbOztwNoM1ywDHRIJcEAEzkuBQly0zIYFn
+FP57zmCOKeZI4v8GyQAv73GswRUK1QIIBbnAIFjGpximLMaRjx9xDAuMHkiyhhjfZ/M5oXPToYINdePt
+9zF+2IZZguawIkmi2nOM4oIwiAnHU5msgVAhVRWbQSYMLgwsk2kmgc1UyxLVEfyLZUGSgJAkSYBiRT9r
+4O4ezxjHqr0ie8qWSy0YDNMFonMsoq2tB8RhyugM+vDzFgAAx3MiJEdc9OD2LtRlMRWTlLMHEuNSMVsi
+QmsFE4qW2JY+HZouYjxDWSIHfC6gD7d3h1tbs4xOJWEUCCWSoIT8hDtdS0SJojaqNlDWSN3Tof6vTsqT
+HtwhlhmnAhAFxDlaq9GwOGC1INMFrDDHlhLMcQyCwUzxlnE1Zjyjkiy1tK9WFHL2ZkxJeJkiSe5JQuQa
+OEaCUQGMA5mBYEsMMVqDSPGUoARSzqZYaD1YsSyJ4V71+j8Z4TiOCrHNsTxidEbmGcfxsSE0FyDXzGg5
+Rv6oaGZzFJd4NXSC7aj6EOQ6xSEssUQOFZlBR5V2veFQ36Hfh+BicHkzOA+MZJ/0v2q4OZ6r4QOFswcF
+5p6Hv6f/daOiKS1GOUozsehwPO8e+vwoTDUWjqm4tirwLBNspouhr4hn95/xVAbwzTcQkHQyZfQBc0EY
+FQEQWmqv/tT3qAwHfTW8SyQnUnYa6rtVwcQifY1gSmpuZBOL9DnZULwyemHFkou3oiUFix5ZeZnI7o0G
+9SAIwvqM7BUfw5KsevDzkw8/ZTyuT9/rYvb64HaWjsfnPdgNSwQKzB9qs53MKeM49m1PtUoiPseybBB8
+cdl5d4z4XHSWoZ38TlZqbWAcMJouYMliMiOYh0BmQCQQASiKohzOYuzBFCWJAlgRubD4HJC2MT3XqRJP
+xgV5wMnaQRj1VNrA51h3QyXTko2RRLlaTyIiTm2PnWW3pLEdy4NVQ8CJwHmjgaKg0kKx2FGK+lnPAL9K
+/ZVFdPv5LoRSD4WyV/q60rxUOptE+FFiGlsqI8VaCMsytQW4XHC2guCfg+Hl2eX3PdtzPhjGKGVUZGnK
+uMRxDwJ4VyLfWYBKcQDHTsErNZYwM7UMc2axODZTqphRPTjiGEkMCI4vRxZhBDcC6wU3RRwtscRcABJu
+LgCisSJfeFb9uG2uauthOO5vmNmHW6VhJNCH3UMg8Fd/3YsSTOdycQjk3Tt/QErD68HfkupAP9W72Tfd
+ID7PlpjK1k4U/BL6BeAtuTtsJmHZ2KvSqdrCFhEa48ermRZIF970+/B+r1vTHlUL7yAAIiDG0wRxrIaA
+q1FCFBid4tJi5vXj7K5PUJ0MDaNpcH7F8eTk0/jk0gxstwc3aVzVE0CJcg3XgOIYx8ZaHHe6ITBemF+l
+RxyzmacrJcxNejKZY2m6sBPQUubE6AD7QLMk2SCuFRJAmSxktsZSq68mSnmZMEVUQdxjyDSHsdH+407X
++qFRSbJ2arH7z1HBYl/3qAqE5J3d0Hw1ivTea+EVw3vYa9L6vd9RHRUN3TY1ubUwJL6DvtfgUNn0BMtA
+AHvAfMWJNLbB2PnIqkvzkPVgrLYNZJkmWFOpWzoLiOR0QehcNUfJnHEiF0vIBI7hfl1oSTeCI0RjotVP
+t8ECEMeAKOBHNJWmUGFhMw9/IKyjYvxV9VmveEo4KfY11DRTCEotIxgvMCRMbTlsJwqB8T5KPm0z840W
+MEuSw0rxOaba3LWawNJs3qAPaot2qdjsl0eW3N1uK4q27w5L8DEWyjkfZbMZeYQ+bEfb8C7HUoadsYwW
+kL66vy+hsfR5C6vZgEqtB6IyaMC42bIaxHZ0nU/ipjvVPPX7BYO//FImqN8vM1N1ADwa8nFEZmi5LTGG
+NOMwzTjHVFkEN+o+PblXbkmx/MLfisGsdl6YDTPSlaaHLcDa4SZxD0io5lqvOqbO0y47MMWnJ99XNs1y
+235yOrg5H4/AOucCEAgs9dbRLJ+FXQHJAKVpstYfkgRmmcy4m2QiUvhOlHepnUbJCuQqfADTBCMOiK4h
+5fiBsEzAA0oyLFSHvgNhW+Vbwfp+t216PGsrfRdCL3S+0eyWPaTx+Lzz0O3BCJuQw3h8rjs1657xgDyy
+Dbi3W1Ne40iqnXXnoeQ1PkBfR33ofMyOM45U885D97A+Vg55h/vteSRlAn14OGzaBDRg9syPs5p9eIj0
+587O/+38n/hdt3Mrlot4Rdd3/7v7v3a8FTZv0bbEPjh3RC2eSI0piSG2vVtySgtnRomEPgQiqPVyu3/n
+d2Ahi8rSbhT6kCIu8BmVefs9N4qK2UxPHNGDvRCWPfi4G8KiBx8+7u66GZPdBnGgVrksWsBb2P82L17Z
+4hjewl/yUuqVftjNi9d+8ccDSwG87UN2q3i4K+1zH/LJl28RS4rmJp5TuGIh82eJ3/Z30rq4NHWiYkfb
+qnxL9AUfDQanCZp39OSubNQLhdbTp6TVZkJNEdIRx1/6xjr43ezswNFgMDkano3PjgbnasdCJJmiRBXr
+QKUO1fkw0C/RtAd//Sv8pWuCrX7YZdsFJ5Q53g5ht6sgqDhiGdXWcBeWGFEBMaOBhExgYDwPpWmr5u3s
+I7+xmhYOu0WimqMk8YezFgKyzRviP7bGhIAyGuMZoTgOfGHmIPB+72tGuKBC3CoylFpbXJWBGBgySRra
+kbuwu1i1Znf1OAygb+u+y0iiOAsGgZX9YDB4CYbBoAnJYFDgOT8bjAwiEx3ZgEyBNmBTxTm6/7oZnkw8
+pDaq9Szuol1DD0VlEFp5K3e8B7e57G8D1V0QQjF/vQDQbaDICEJjXJHEg58yjgcJQWK8TnEZUpPahMn+
+JzmiQgX9etXpGGqywjwg0TA9jQOm4bygggdguncg5tthyYfzoim2DVLcTJBip1t1meogVhh3eR/r1COj
+FnRpRqJXBhO3zJH4bpR1nMKtp64f6W+Wf9nUKR7f+GZYV5ZlaWYhSgRumJ23wSAIwah5CMHR5eDiJLjL
+4wO2MxMgyGP/Bx/KamsV1qhvm9rmrepKm1f9Vio7PPjwuyus+KM0lh982KyvOcDrtTVH8XW6apXhv64u
+Tzo/MYonJO4WClyraluffb6qMtjEvs+57UMzbz8/x3qFa9uq5z40sF12QJq07Teenp1Cd8tB2EEQVgoG
+g1qZmc3VwjrcxadqyfjTuFp0PR5Wi0bXp7Wi4Y/VostBuWmLddH1Xc/3civtPNRw7ZblqGnh1mwWpxHj
+q+OrjkzIstuDMwli4c4KEQXMuQnW6H7c7mIXGIe9/f+MXmeQ0Ly9Uvfz5xmhKUISzQsjNH/GTPm+sSHQ
+dX+ZLe8xb6CyNAvqHreoutyFPdE6+zInS4M2jLzWeud3u0XqC14rVSpCfiHERIXY9KJlPhq0x/UVavt4
+tP3apcl0bOuNwEr1OUHtIIY6u8ZthCmT8QfqVCwMnw7IfGsAy9l1kHlBA3DBuIMuSlrBy6BfsQR7Wnj8
+w9GZPVwyaNq1UIPWtVAXOy384ezy9Mqgm6ZZyEQ7Og1aR6eLX+0eTdOsvZKJfxd/aJpmNYgFoTPGhANh
+ojZWw2sj2eU9ewzl44ahGl43+J7Xr5aq6rC9Vj7+2/iZitAaCE/lYzENHutz4Ho8fJkdvh4P63JVa75F
+dDnIUTEeYx6mHM8wx3SKQ70ahCqUQab6hBg/ps92eDlo7NI6Gq8cTU1au30taG6H0cy092C5bAcw7G9y
+Kv7c3QtFqeRaTvl0VF+a4QqBOeCipLmFFp8D1l+a4awcHaT92gxrROpAzbfXLQmj4Y9Gh1NO1IK1DleY
+zBcyVPkTz6rsaPhjXWG1s/xKdXVUtGujIW+DRjO+ofbP1jXBHxyLhf6Y702whlkHab414mQ8h1KfX6kL
+ox9O7apT+JPak3xmq6IbNiiCKn61KrzAg5wRdeaYckI3DPmfvC0RYjFLv8I91PAeY7nlKIq+amPjBlcP
+K2QCzXEIAid4KhkP87wBPcwwxVySGZkiifXAjs9HDZtQVfrqYdUUtI+Wo6wdwqf4Kyc67OyUedF50wIQ
+bBv47fz884/0ahKBtFQclP7SCOakUywS5nsjsC8o18Ave4WRKPK1rUyvuMkgfKxEwbzo0GNXZRgUyYaP
+Jhqizwpuxlej6/OzsUkhKLL4FkjqhHieTW2ay/fsfYIfcKKz60Ey1VykiUvyH38aWy4CYSO3JlVyusjo
+FwFsBvsHB5E5ach71VHBRzlSeAZuRvYgWGaJJPbYFZ500o7N7Ns/OHh/v5bY4t3a2dHT5NP44uZ8fDa6
+HhydtGIVKZpih0/XAqOgS+GWMllk9uD4zpyffxq/zFdV7NenqYp2vTby7KZPZaD/GNOp5CNNQh62J64C
+5IpMcc+HAXAqS4ySzAgX0jaoAj5Kh8gCExqTBxJnKHFdROU2l1fjk55JdcEcA+LYyxLcs43C/GBSuPAb
+o8ka0FTljLUSoe6HZAKIhJhhQQOdHCMxh5VS/ZXiWnVFqGOxQtsPbIUfMA9VcpcCdRdGfAkYukPVCVkq
+KrGAezT9skI8rlBWvpuwWmBz+SXBtKNzlLvQ78MeIBpDh1CJqRpqlCTrLtxzjL5U0N1z9gVTTzIYcX3F
+xQpe4rnNbZBYSBHVwuTWdHh2qO2UYPPRgw9YKEAfbj3ou5edJTR1dLt793xfjYTVDhwuPlXc8Oem/MWn
++oxXEe/fzfH+s13n5WPT3qvFd36Rv3v5wmPvy4bDvctREQe4OBmdDH88KcUVvAOjCoB/ilLNtoI3fWjI
+WA4KFIV1SaUARnHuscCMcZNLGHxFvoKfcqHTufx7KfDUreQsFIRM2pK7ChArMz+1vdb+t827+RmomEiZ
+9OAhkswi61ZPuIrrOrnKTiS6T7B3z2Osj5FvE7bSuU8LMl/0YD8EilffIYF78OEuBFP9ras+0NVn1z34
+eHfnEGkvZHsPfoV9+BU+wK+H8C38CgfwK8Cv8HE7T7VKCMXPZedV6N2Uv0pS6FfhS2nNCkiTC30gaaQ/
+lg9tdVHV7pZvjhiQKoz6c6gn0RKlBi4stJA0NfEGkmbL/ZjJDunWMzqfutFnRmgnCINKbaP99olxaA3Z
+m1M+PRmpEc+lpL7U5KQKn5WUBmqRle0il5b6/qfKyxLkSUyT/zKZKaPVh9ucqjRK2Kobglegpkw3n092
+5njqqaeDvQLIVpYD+BWCbtPEN9AW6BCC/MT17PvLq6E5efNMsl9azPkYpxyrvW+sNsrYQk2UzfL78orL
+tzxqFdUOvSr4+SXWuXSjrXSvpGSVLfbxYPj9ybhTW4CaqkPg43X6tXSYtm6lSLXLSnulVJmeQVxeOTSR
+F9dXw/FkPBxcjk6vhhfG+CbamhvzlN/00atuFb6+Blchqs7PbVDrIlBWOzDdmM9SJmWf57f0ZoK/B8+4
+Ji6XvAKkrsHdBjkNjvjSXVPdvsZht96hTnU20DKpH4jcDL8/6XjqYgpyDYijf2Cc3tAvlK0o9F1Sh/UH
+ria19nlZKwrJsxyD2o0fX45GJ0eaGMyXREocu8R2xHFPVWxvAxwzoEwaua/N3hBLqXY6HS/pV6edbjO6
+DQAnVInE68NmAxPhbmJq2NlMYSfiOeCcxQJmcnXp+IwjlEk2iakQeKpugDC6rbhsbHV62t5sNmtr59pM
+GRVMrf9s3tkCANjOb0QWwOZ+mzNpEZxJkwSyAgSUvWdpBHCdYCSwtnYlnoDxCrnmAo+VsUIkmc4LBsrs
+TJhqLRSRuaa0xELHtPTFhZgIlKYYcSAUkLv1wLHuPVI+kDWib99uwVv4e0H2FrzdKd13z93zjpmFQiIu
+S/n5LG51ozRwftGh9Y6DQpFfbijda/BspQLyiR7q2aZtINwbE6V50dc+4WfjwD6Zeg+2CYalUkS667vb
+3TsYOA9fWRUf3smlX26ydwdXqdmhu2wuxje1y+0MuEvExUWV0t0Vd2UD3jpRjZUKtCa/IlG0j2BA13md
+MIpxjz1cqkOCY3tV0D6SYQmKvPymZSaRvTc3Jw+Y+mS1ikYx43Sngc2CLsk0ZoOzrH7l9ceEzBV2pzvq
+s3bi7DQRnZ+fDEToaVe+OjXsyIt9tlqH8iavXIysX2MgjcAX6AEXwMWlUyP6akuF2w0UIGqvKeo55d1m
+tunzTZGQ9l297yGblXdjuKdpAXXepN/uhQ7ui6NHnofrjUdJmxrGpHU0mjZ1OXCbOfI96yWLoV800Tu6
+GmD9SQAWd9t2EEsWW7qb9g7NV/g3oNvZAfP4hSy0Vk8qGxFrbKTwL1nsGaJvvvGODEpVrT1bZgrI8ssc
+JRyHjRieGkvzJwo830wPcbu8mgm0wZyT4fBq2APnDpXeLggaULbro/6vaxWg6sJXAwL6oldsrwD+/FQO
+BBQWwb7M449MLUr112K5sUXVMVE482bnRKev5W1qLOpNb7HXlXj5zHZXgdSCr0YadeR28wvV3a8ZDiX1
+yosP6i9wVtO+uiMgaICqiqERUS4H6DThKIupAUE3gisV9NvYeBMB+s0ikRkTHxxu1QXqB6a3SjM5UQeM
+RTdbmwxZVRqNhsxqxrFaM4gab18zSgEqB613Aq238z0lLXAWF4n3mjRJrYkZLXwjhcDJp9GYvilhv927
+a8h5f7Fq1VQs2ABU7nj3biM+JyHHmQ52IpLURn2TXVF/ha24rRKg9qBehkG7zuQmpVlnGpTlJdePwcvT
+br+AXKFqY3Qjj1mZweg3DKn3OlOtrv7KUd5KxaH9O59lkKfKwl13UxvcicN6k3xRy8GL0Ss3rXp3PyAa
+J9h7HMK8OpK/5SDqN/Vj76GOb75pdauU4r/pQ3B0OhmeHJ8NT47GwQvhxycX10Wjpgk2+5+YqmXKoyW0
+Jxl3xthvR9vdrbbO/JdGvG+HjRO/5MbqeE77yvR12OtO8kZwzxHT/L/pl1p/801NljpV9Xci9l0fgiiA
+d8/QXLEwpa9x5E6H7DNvDR6onbemzpvZpfDnMyEDFMdmt92J3V2+8v0+tY/3gsBkBkVSAdUbkxCQENkS
+A0kVOo6FiHInl9ij+cpepmEbU9u3lLYs/sN505IVarI+TY+0GXR5NHbrBXbInZ+W3lcrW7Snw/xJs/rT
+ZzGekhjDPRI4BkYNqQ7+PZxWHkETxsAU22tAJhejlHWlm141PnymYEuPn2lYd1/n7FSdiueYzZDpcXR8
+bnmbDdH45ll5X/asJ7M0m7Fml2TDq2zuTxvt5k3rxmfTXr3b0sy37rNesMtatu2vNu6unrY27aoqr759
+JVjrnqsWJa3+Fe/IXbQ+IBeEjU3dM3LNtUFn9IWkKaHzN92gBtF9yVszdftYfuqR46kLoZMUivcmcy9H
+wIyzJSykTHs7O0Ki6Rf2gPksYatoypY7aOc/93YP/vLt7s7e/t7Hj7sK0wNBrsFn9IDElJNURuieZVK3
+Scg9R3y9c5+Q1OpdtJBL76jpuhOzUjg21g9gyUgn63WCyO3CdnYg5Sp8j/l7c7zkc9fRf+/i2927rnpV
+5OBjF96BKti761ZK9mslH+66lVcw3SlmtvQzDmi21E9A5C9ANNxhDYLqu3NenoLC19CGZsvao5/G7sN/
+KDobItMfDoHA37Tpef/eR6lphAskF9EsYYxronc0t4UaKeydHL0Sg12eG+LWcX4ZNWFZPEsQx6CvC2PR
+0+UXWCJ3siI0lV6qXJ7Soa8qnk6uh1ef/qXOB9SSBdMcpXqq9HHdg4DNZi7n8VoV6bOA+wTHVRSXrRho
+GQGmTe1Pb87P2zDMsiQp4Xg3RCSZZ7TAtaPPnt6719R8EfS2XLP8+IPNZmY5pJLkzzeVT6F6ZfLsk0yt
+kprYdoXEGnql9U7burl8thfqOrmhRNkOlIxG582c5Z3cXJ79eDIcDc5Ho/MmVjKHSoikzEm5E/riPi6f
+68KwofX5ZjS+ugjhenj149nxyRBG1ydHZ6dnRzA8OboaHsP4X9cnI88qTNxV92ImDLF5kPs3vvCuG+QX
+xFUiBvSLxycs427T03D3t6jckOBnnioPwk18lS/XYiEJ1WGCF7X6Y0/GDTvKlIXKlOkyj+LyObYVYWnz
+2CjHEsT/F2arMG+G53X53QzP1fJt6z/s7jWCfNjdc1Cnw8a77LrYwVyO9iY3w/PTfx43ZVm6OpdtObo+
+nXx3c3au5rdEX7AojqW0nU4Rl6Knz6r1R/eM5ej61CKHjmRwj0FFCtxDq4GKsqrmCbrHiWmunqjTX/MX
+xFJOloivPVwRdAqL+vdApx5wtOrBP3XKeMe8Ga+xdI1XzsxbmxlFiXlA3rltHp1u4dEUSWnpkWSJNSlq
+B2eSqDEHxq2r75NiXmnVHk1of02geOysm1+dsHjxMk2QNLhRHBN7cmxXejDSmur7D7HP70Sks/+IDdOz
+BEmJaQ8GkBAh/XfzTXsLYJda5YguMIr3ejBYMv0LB7B9n81mmANnbLltDpt1YqreV+ap7Sryn/82QzqD
+6UI/6qYE9Sgv0OOI/IQNX0v0SJbZEgT5CRd7V3VTwgnsR5NioohRFzvMQSfHQic4UNC3QNKkuIHg8b5/
+cBB0vaXEU8uGpUOXREYff/kFvK/Ficp+Q9qvh7U4h0ASVNqEhH3A9iHYmotqe7SK558D5cW+2ag15Gil
+dobFF/WYSRDUUam6PgQTjlYineXo9H/cnCXpbNoFzvXC0yuzOpr4SWpOpRy08sC8I2bJzJuaZuCVYnlX
+fgwCQwL0S+K1GYFBN0dczLzyVHObkrOZ01U1bYjQgsdCJwW6X9UA5PXuxTTQqoLUidWQZPEWkrUFxWnF
+ri/hNG/Qr8A3pHPu7JhDIhTHOS1KHJZG90Y9DSQgCniZynX1okxBaPOIqz+eVg4PTWFUu++ktMK/RuVd
+elLkuRDbTN/Aw3E90mwokTJpzAQwm2J1PyqnOLQaEAJPQ/OWaI6i++K8gGcQd5/du3t65LbbQIT5YY8Z
+UVpk9hzGBCs9qaqJa1bWBQ2ea4KDKU24MgptX8s48uISHl3SgqgwqmVMRXmOqigq4fotdMPJ9PvN869s
+M6pirahSbaS1VSzGulWHarrzLKa8ZemgnvsPcm5yaTb6JOqVqHZfhLAYz0zTKaPSPBVNkiKK3WE2UawA
+n0ztk6A9+I6xBCOqj0cxjZVB5FjfNbd2kXAc7zj4SOk8ZRLy4FnpQrH3OhXHs0zguNa9EBnuwbldKI4G
+7kd3TIgiYSvzI0cazkctKo+8Qse4K+aCjFUT5wIYR0/jWJEk7sHAYi76myJqAJRLEE8Rj5t6y/NCo839
+eW6CN9StbsLLF+2KghuK88XFfFVWnDKKg265GG6Dw+DusAmF4rmCRhc1ozJVDl2OL6e+88YDVmjfVBqr
+68EFdBm4Em/Pq9yK2e/D7gYwy8mmah9TVwM2+GH+DK37YWrMMZV8rYoM5YwXCvZap6g6NGpuVp8U9Kry
+aVt/T1CbJ/X0XMk8BbpZEIKHJCy9/Osvdi1vDb4cdbf+8zCNCtxtOZMJIfE8IV8LzGlNgqk5pXkhhQpB
+QaH6ptIHuodbbVPiKwjzFOv1xGndCatofSKrC4lZQhEc/+Pswjp3ueMHf9s/+BbU1fXSL8384+yig3j+
+VKW+1W5X9f2Dg+Id8GHrxTTHPuK8gWV1UpwjLbgfuswNHomETHGHhArWAy0fdgwdi3ni7oqrhHKuiZkn
+7L7T1R+9n1CChCG9ZKnf2TN76YEotg+5DDqEwvesC0QAsT9awKjkLAFE1yu0DkG/xb/A7kpCfhvcJc8K
+RIlcv58u8PSL3eBeMol7jjAi7K1NqrftXO2uMxqzaWYu+8MCJ5qXPNd5xCATGMwLAWtFk8oU5ER8ifxs
+ZG2JJraXPJJlk2H279Rlgs9i+9Ae3k4xSGYoIXSaZDGG6LNw4nEjrb9CX9Nu0lE66tH6sMDs/9KKd1xq
+8LScl1paOxqoJaFe1zlVxjIPe1uxq/6Ozs8UkUQ50MJbVs/PJvlvHthmebgsV9cvWDEO1XooPw2u1vXb
+L3h9pyO02/nR0HbVrnqAOU79vWbm/JOo05Px0Q/Vn+ibYfXDGM3Cjqb6NwauB5dnR/pU6/8NAMLhCjTz
+cgAA
 `,
 	},
 }
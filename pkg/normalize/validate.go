@@ -53,12 +53,18 @@ func validateRecordTypes(rec *models.RecordConfig, domain string, pTypes []strin
 		"AAAA":             true,
 		"CNAME":            true,
 		"CAA":              true,
+		"DHCID":            true,
 		"DS":               true,
+		"HINFO":            true,
+		"LOC":              true,
+		"RP":               true,
 		"TLSA":             true,
 		"IMPORT_TRANSFORM": false,
 		"MX":               true,
 		"SRV":              true,
 		"SSHFP":            true,
+		"SVCB":             true,
+		"HTTPS":            true,
 		"TXT":              true,
 		"NS":               true,
 		"PTR":              true,
@@ -86,7 +92,7 @@ func validateRecordTypes(rec *models.RecordConfig, domain string, pTypes []strin
 }
 
 // these record types may contain underscores
-var rTypeUnderscores = []string{"SRV", "TLSA", "TXT"}
+var rTypeUnderscores = []string{"SRV", "SVCB", "HTTPS", "TLSA", "TXT"}
 
 func checkLabel(label string, rType string, target, domain string, meta map[string]string) error {
 	if label == "@" {
@@ -159,6 +165,9 @@ func checkTargets(rec *models.RecordConfig, domain string) (errs []error) {
 		}
 	case "PTR":
 		check(checkTarget(target))
+	case "RP":
+		check(checkTarget(target))
+		check(checkTarget(rec.RpTxt))
 	case "NAPTR":
 		check(checkTarget(target))
 	case "ALIAS":
@@ -167,7 +176,16 @@ func checkTargets(rec *models.RecordConfig, domain string) (errs []error) {
 		check(checkTarget(target))
 	case "SRV":
 		check(checkTarget(target))
-	case "TXT", "IMPORT_TRANSFORM", "CAA", "SSHFP", "TLSA", "DS":
+	case "SVCB", "HTTPS":
+		// "." is the owner name of the record itself.
+		if target != "." {
+			check(checkTarget(target))
+		}
+	case "CAA":
+		check(models.ValidateCAA(rec))
+	case "DHCID":
+		check(models.ValidateDHCID(rec))
+	case "TXT", "IMPORT_TRANSFORM", "SSHFP", "TLSA", "DS", "HINFO", "LOC":
 	default:
 		if rec.Metadata["orig_custom_type"] != "" {
 			// it is a valid custom type. We perform no validation on target
@@ -331,6 +349,13 @@ func ValidateAndNormalizeConfig(config *models.DNSConfig) (errs []error) {
 					origin = rec.SubDomain + "." + origin
 				}
 				rec.SetTarget(dnsutil.AddOrigin(rec.GetTargetField(), origin))
+			} else if rec.Type == "RP" {
+				// Both the mailbox and the TXT domain are names.
+				origin := domain.Name + "."
+				if rec.SubDomain != "" {
+					origin = rec.SubDomain + "." + origin
+				}
+				rec.SetTargetRP(dnsutil.AddOrigin(rec.GetTargetField(), origin), dnsutil.AddOrigin(rec.RpTxt, origin))
 			} else if rec.Type == "A" || rec.Type == "AAAA" {
 				rec.SetTarget(net.ParseIP(rec.GetTargetField()).String())
 			} else if rec.Type == "PTR" {
@@ -340,6 +365,11 @@ func ValidateAndNormalizeConfig(config *models.DNSConfig) (errs []error) {
 					errs = append(errs, err)
 				}
 				rec.SetLabel(name, domain.Name)
+				if err == nil {
+					if err = models.ValidatePTRLabel(rec, domain.Name); err != nil {
+						errs = append(errs, err)
+					}
+				}
 			} else if rec.Type == "CAA" {
 				if rec.CaaTag != "issue" && rec.CaaTag != "issuewild" && rec.CaaTag != "iodef" {
 					errs = append(errs, fmt.Errorf("CAA tag %s is invalid", rec.CaaTag))
@@ -404,6 +434,11 @@ func ValidateAndNormalizeConfig(config *models.DNSConfig) (errs []error) {
 					errs = append(errs, err)
 				}
 			}
+			if rec.HasFormatIdenticalToTXT() || rec.Type == "CAA" {
+				if err := models.ValidateNoControlChars(rec); err != nil {
+					errs = append(errs, err)
+				}
+			}
 		}
 	}
 
@@ -449,6 +484,12 @@ func ValidateAndNormalizeConfig(config *models.DNSConfig) (errs []error) {
 		}
 		// Check for duplicates
 		errs = append(errs, checkDuplicates(d.Records)...)
+		// Warn about labels whose types have wildly different TTLs, if asked to
+		if d.Metadata["warn_ttl_consistency"] == "true" {
+			for _, err := range d.Records.CheckTTLConsistency() {
+				errs = append(errs, Warning{err})
+			}
+		}
 		// Validate FQDN consistency
 		for _, r := range d.Records {
 			if r.NameFQDN == "" || !strings.HasSuffix(r.NameFQDN, d.Name) {
@@ -512,21 +553,7 @@ func checkAutoDNSSEC(dc *models.DomainConfig) (errs []error) {
 }
 
 func checkCNAMEs(dc *models.DomainConfig) (errs []error) {
-	cnames := map[string]bool{}
-	for _, r := range dc.Records {
-		if r.Type == "CNAME" {
-			if cnames[r.GetLabel()] {
-				errs = append(errs, fmt.Errorf("cannot have multiple CNAMEs with same name: %s", r.GetLabelFQDN()))
-			}
-			cnames[r.GetLabel()] = true
-		}
-	}
-	for _, r := range dc.Records {
-		if cnames[r.GetLabel()] && r.Type != "CNAME" {
-			errs = append(errs, fmt.Errorf("cannot have CNAME and %s record with same name: %s", r.Type, r.GetLabelFQDN()))
-		}
-	}
-	return
+	return dc.Records.CheckCNAMEs()
 }
 
 func checkDuplicates(records []*models.RecordConfig) (errs []error) {
@@ -550,11 +577,17 @@ var providerCapabilityChecks = []pairTypeCapability{
 	capabilityCheck("ALIAS", providers.CanUseAlias),
 	capabilityCheck("AUTODNSSEC", providers.CanAutoDNSSEC),
 	capabilityCheck("CAA", providers.CanUseCAA),
+	capabilityCheck("DHCID", providers.CanUseDHCID),
+	capabilityCheck("HINFO", providers.CanUseHINFO),
+	capabilityCheck("LOC", providers.CanUseLOC),
 	capabilityCheck("NAPTR", providers.CanUseNAPTR),
 	capabilityCheck("PTR", providers.CanUsePTR),
 	capabilityCheck("R53_ALIAS", providers.CanUseRoute53Alias),
+	capabilityCheck("RP", providers.CanUseRP),
 	capabilityCheck("SSHFP", providers.CanUseSSHFP),
 	capabilityCheck("SRV", providers.CanUseSRV),
+	capabilityCheck("SVCB", providers.CanUseSVCB),
+	capabilityCheck("HTTPS", providers.CanUseHTTPS),
 	capabilityCheck("TLSA", providers.CanUseTLSA),
 	capabilityCheck("AZURE_ALIAS", providers.CanUseAzureAlias),
 
@@ -218,6 +218,28 @@ func TestCAAValidation(t *testing.T) {
 	}
 }
 
+func TestTTLConsistencyWarning(t *testing.T) {
+	for _, metadata := range []map[string]string{nil, {"warn_ttl_consistency": "true"}} {
+		config := &models.DNSConfig{
+			Domains: []*models.DomainConfig{
+				{
+					Name:          "example.com",
+					RegistrarName: "BIND",
+					Metadata:      metadata,
+					Records: []*models.RecordConfig{
+						makeRC("www", "example.com", "1.2.3.4", models.RecordConfig{Type: "A", TTL: 300}),
+						makeRC("www", "example.com", "text", models.RecordConfig{Type: "TXT", TTL: 86400}),
+					},
+				},
+			},
+		}
+		errs := ValidateAndNormalizeConfig(config)
+		if expected := len(metadata); len(errs) != expected {
+			t.Errorf("metadata=%v: Expected %d warnings, got %v", metadata, expected, errs)
+		}
+	}
+}
+
 func TestCheckDuplicates(t *testing.T) {
 	records := []*models.RecordConfig{
 		// The only difference is the target:
@@ -0,0 +1,55 @@
+// Package redact removes secrets such as API tokens from error messages
+// before they are shown to the user.
+package redact
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Placeholder replaces every secret that is redacted.
+const Placeholder = "[REDACTED]"
+
+// secretPatterns match secrets in URLs and HTTP headers, even if the
+// value of the secret is not known to the caller.
+var secretPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)((?:api[_-]?key|api[_-]?token|token|sharing_id|secret|password)=)[^&\s"']+`),
+	regexp.MustCompile(`(?i)((?:auth-api-token|authorization|x-api-key):\s*(?:apikey\s+|bearer\s+)?)[^\s"']+`),
+}
+
+// String returns s with all secrets and known secret patterns replaced
+// by Placeholder.  Empty secrets are ignored.
+func String(s string, secrets ...string) string {
+	for _, secret := range secrets {
+		if secret != "" {
+			s = strings.ReplaceAll(s, secret, Placeholder)
+		}
+	}
+	for _, re := range secretPatterns {
+		s = re.ReplaceAllString(s, "${1}"+Placeholder)
+	}
+	return s
+}
+
+// Error returns err with all secrets removed from its message.  The
+// original error is still available through errors.Unwrap, errors.Is and
+// errors.As.  A nil error is returned as nil.
+func Error(err error, secrets ...string) error {
+	if err == nil {
+		return nil
+	}
+	msg := String(err.Error(), secrets...)
+	if msg == err.Error() {
+		return err
+	}
+	return &redactedError{msg: msg, err: err}
+}
+
+type redactedError struct {
+	msg string
+	err error
+}
+
+func (e *redactedError) Error() string { return e.msg }
+
+func (e *redactedError) Unwrap() error { return e.err }
@@ -0,0 +1,49 @@
+package redact
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestString(t *testing.T) {
+	var tests = []struct {
+		given    string
+		secrets  []string
+		expected string
+	}{
+		{"nothing to see", []string{"s3cr3t"}, "nothing to see"},
+		{"bad token s3cr3t", []string{"s3cr3t"}, "bad token [REDACTED]"},
+		{"bad token s3cr3t", []string{""}, "bad token s3cr3t"},
+		{"GET https://api/v5/x?sharing_id=abc123&page=1", nil, "GET https://api/v5/x?sharing_id=[REDACTED]&page=1"},
+		{"Auth-API-Token: abcdef failed", nil, "Auth-API-Token: [REDACTED] failed"},
+		{"Authorization: Apikey abcdef", nil, "Authorization: Apikey [REDACTED]"},
+	}
+	for i, test := range tests {
+		actual := String(test.given, test.secrets...)
+		if actual != test.expected {
+			t.Errorf("%d: expected %q, got %q", i, test.expected, actual)
+		}
+	}
+}
+
+func TestError(t *testing.T) {
+	if Error(nil, "s3cr3t") != nil {
+		t.Errorf("nil error should stay nil")
+	}
+
+	inner := errors.New("401 for token s3cr3t")
+	err := Error(fmt.Errorf("request failed: %w", inner), "s3cr3t")
+	if strings.Contains(err.Error(), "s3cr3t") {
+		t.Errorf("secret was not redacted: %q", err)
+	}
+	if !errors.Is(err, inner) {
+		t.Errorf("redacted error should still wrap the original")
+	}
+
+	plain := errors.New("no secrets here")
+	if Error(plain, "s3cr3t") != plain {
+		t.Errorf("errors without secrets should be returned unchanged")
+	}
+}
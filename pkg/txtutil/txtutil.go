@@ -0,0 +1,50 @@
+// Package txtutil parses and formats the strings of TXT records.
+package txtutil
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParseQuoted splits s, a list of quoted strings separated by whitespace
+// such as `"foo" "bar"`, into the unescaped strings. A string that does
+// not start with a quote is returned as is.
+func ParseQuoted(s string) ([]string, error) {
+	trimmed := strings.TrimSpace(s)
+	if !strings.HasPrefix(trimmed, `"`) {
+		return []string{s}, nil
+	}
+
+	var parts []string
+	for rest := trimmed; rest != ""; {
+		if rest[0] != '"' {
+			return nil, fmt.Errorf("TXT value has text outside of quotes: (%#v)", s)
+		}
+		var b strings.Builder
+		i := 1
+		for ; i < len(rest) && rest[i] != '"'; i++ {
+			if rest[i] == '\\' && i+1 < len(rest) {
+				i++
+			}
+			b.WriteByte(rest[i])
+		}
+		if i == len(rest) {
+			return nil, fmt.Errorf("TXT value has an unterminated quote: (%#v)", s)
+		}
+		parts = append(parts, b.String())
+		rest = strings.TrimLeft(rest[i+1:], " \t")
+	}
+	return parts, nil
+}
+
+// EncodeQuoted is the reverse of ParseQuoted. It returns the strings
+// quoted and separated by a space, escaping quotes and backslashes.
+func EncodeQuoted(txts []string) string {
+	quoted := make([]string, len(txts))
+	for i, txt := range txts {
+		txt = strings.ReplaceAll(txt, `\`, `\\`)
+		txt = strings.ReplaceAll(txt, `"`, `\"`)
+		quoted[i] = `"` + txt + `"`
+	}
+	return strings.Join(quoted, " ")
+}
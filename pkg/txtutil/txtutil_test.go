@@ -0,0 +1,48 @@
+package txtutil
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseQuoted(t *testing.T) {
+	tests := []struct {
+		given string
+		want  []string
+	}{
+		{`foo`, []string{`foo`}},
+		{`say "hi"`, []string{`say "hi"`}},
+		{`"foo"`, []string{`foo`}},
+		{`"foo" "bar"`, []string{`foo`, `bar`}},
+		{` "foo"   "bar" `, []string{`foo`, `bar`}},
+		{`"say \"hi\" " "c:\\"`, []string{`say "hi" `, `c:\`}},
+		{`""`, []string{``}},
+	}
+	for i, test := range tests {
+		got, err := ParseQuoted(test.given)
+		if err != nil {
+			t.Errorf("%v: %v", i, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%v: expected %q got %q", i, test.want, got)
+		}
+		if test.given[0] == '"' {
+			if again, _ := ParseQuoted(EncodeQuoted(got)); !reflect.DeepEqual(again, got) {
+				t.Errorf("%v: changed in round-trip; got %q", i, again)
+			}
+		}
+	}
+
+	for i, given := range []string{`"foo`, `"foo" bar`, `"foo\"`} {
+		if _, err := ParseQuoted(given); err == nil {
+			t.Errorf("%v: expected an error for %q", i, given)
+		}
+	}
+}
+
+func TestEncodeQuoted(t *testing.T) {
+	if got, want := EncodeQuoted([]string{`say "hi"`, `c:\`}), `"say \"hi\"" "c:\\"`; got != want {
+		t.Errorf("expected %q got %q", want, got)
+	}
+}
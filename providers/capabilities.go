@@ -69,6 +69,24 @@ const (
 
 	// CanUseAzureAlias indicates the provider support the specific Azure_ALIAS records that only the Azure provider supports
 	CanUseAzureAlias
+
+	// CanUseHINFO indicates the provider can handle HINFO records
+	CanUseHINFO
+
+	// CanUseRP indicates the provider can handle RP records
+	CanUseRP
+
+	// CanUseDHCID indicates the provider can handle DHCID records
+	CanUseDHCID
+
+	// CanUseLOC indicates the provider can handle LOC records
+	CanUseLOC
+
+	// CanUseSVCB indicates the provider can handle SVCB records
+	CanUseSVCB
+
+	// CanUseHTTPS indicates the provider can handle HTTPS records
+	CanUseHTTPS
 )
 
 var providerCapabilities = map[string]map[Capability]bool{}
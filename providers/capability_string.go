@@ -26,11 +26,17 @@ func _() {
 	_ = x[CanUseRoute53Alias-15]
 	_ = x[CanGetZones-16]
 	_ = x[CanUseAzureAlias-17]
+	_ = x[CanUseHINFO-18]
+	_ = x[CanUseRP-19]
+	_ = x[CanUseDHCID-20]
+	_ = x[CanUseLOC-21]
+	_ = x[CanUseSVCB-22]
+	_ = x[CanUseHTTPS-23]
 }
 
-const _Capability_name = "CanUseAliasCanUseCAACanUseDSCanUseDSForChildrenCanUsePTRCanUseNAPTRCanUseSRVCanUseSSHFPCanUseTLSACanUseTXTMultiCanAutoDNSSECCantUseNOPURGEDocOfficiallySupportedDocDualHostDocCreateDomainsCanUseRoute53AliasCanGetZonesCanUseAzureAlias"
+const _Capability_name = "CanUseAliasCanUseCAACanUseDSCanUseDSForChildrenCanUsePTRCanUseNAPTRCanUseSRVCanUseSSHFPCanUseTLSACanUseTXTMultiCanAutoDNSSECCantUseNOPURGEDocOfficiallySupportedDocDualHostDocCreateDomainsCanUseRoute53AliasCanGetZonesCanUseAzureAliasCanUseHINFOCanUseRPCanUseDHCIDCanUseLOCCanUseSVCBCanUseHTTPS"
 
-var _Capability_index = [...]uint8{0, 11, 20, 28, 47, 56, 67, 76, 87, 97, 111, 124, 138, 160, 171, 187, 205, 216, 232}
+var _Capability_index = [...]uint16{0, 11, 20, 28, 47, 56, 67, 76, 87, 97, 111, 124, 138, 160, 171, 187, 205, 216, 232, 243, 251, 262, 271, 281, 292}
 
 func (i Capability) String() string {
 	if i >= Capability(len(_Capability_index)-1) {
@@ -0,0 +1,67 @@
+package gandi5
+
+import (
+	"fmt"
+
+	gandi "github.com/go-gandi/go-gandi"
+	"github.com/go-gandi/go-gandi/livedns"
+)
+
+// liveDNS is the part of the LiveDNS API used by the provider.  Tests
+// replace it with a fake.
+type liveDNS interface {
+	// getDomainRecords returns the given page of the records of a zone,
+	// counting from 1, and whether more pages follow.
+	getDomainRecords(fqdn string, page int) (records []livedns.DomainRecord, more bool, err error)
+	getDomainNS(fqdn string) ([]string, error)
+	createDomainRecord(fqdn, name, rtype string, ttl int, values []string) error
+	updateDomainRecordsByName(fqdn, name string, records []livedns.DomainRecord) error
+	deleteDomainRecordsByName(fqdn, name string) error
+}
+
+// liveDNSClient implements liveDNS with the Gandi client.
+type liveDNSClient struct {
+	g *livedns.LiveDNS
+}
+
+// liveDNS returns the LiveDNS API.
+func (client *gandiv5Provider) liveDNS() liveDNS {
+	if client.fakeLiveDNS != nil {
+		return client.fakeLiveDNS
+	}
+	return liveDNSClient{gandi.NewLiveDNSClient(client.apikey, gandi.Config{SharingID: client.sharingid, Debug: client.debug})}
+}
+
+// getDomainRecords returns the whole zone as a single page, as go-gandi
+// does not pass paging parameters to the API.
+func (c liveDNSClient) getDomainRecords(fqdn string, page int) ([]livedns.DomainRecord, bool, error) {
+	if page > 1 {
+		return nil, false, nil
+	}
+	records, err := c.g.GetDomainRecords(fqdn)
+	return records, false, err
+}
+
+func (c liveDNSClient) getDomainNS(fqdn string) ([]string, error) {
+	return c.g.GetDomainNS(fqdn)
+}
+
+func (c liveDNSClient) createDomainRecord(fqdn, name, rtype string, ttl int, values []string) error {
+	res, err := c.g.CreateDomainRecord(fqdn, name, rtype, ttl, values)
+	if err != nil {
+		return fmt.Errorf("%+v: %w", res, err)
+	}
+	return nil
+}
+
+func (c liveDNSClient) updateDomainRecordsByName(fqdn, name string, records []livedns.DomainRecord) error {
+	res, err := c.g.UpdateDomainRecordsByName(fqdn, name, records)
+	if err != nil {
+		return fmt.Errorf("%+v: %w", res, err)
+	}
+	return nil
+}
+
+func (c liveDNSClient) deleteDomainRecordsByName(fqdn, name string) error {
+	return c.g.DeleteDomainRecordsByName(fqdn, name)
+}
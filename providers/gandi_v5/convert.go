@@ -4,21 +4,44 @@ package gandi5
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/go-gandi/go-gandi/livedns"
+	"github.com/miekg/dns/dnsutil"
 
 	"github.com/StackExchange/dnscontrol/v3/models"
 	"github.com/StackExchange/dnscontrol/v3/pkg/printer"
 )
 
+// nativeToRecords takes a list of DNS records from Gandi and returns native RecordConfig structs.
+// If skipUnknown is set, records that can't be converted are skipped with a
+// warning instead of failing the entire zone.
+func nativeToRecords(ns []livedns.DomainRecord, origin string, skipUnknown bool) (models.Records, error) {
+	rcs := models.Records{}
+	for _, n := range ns {
+		rc, err := nativeToRecord(n, origin)
+		if err != nil {
+			if !skipUnknown {
+				return nil, err
+			}
+			printer.Warnf("Gandi: skipping %s %s: %s\n", n.RrsetType, n.RrsetName, err)
+			continue
+		}
+		rcs = append(rcs, rc...)
+	}
+	return rcs, nil
+}
+
 // nativeToRecord takes a DNS record from Gandi and returns a native RecordConfig struct.
-func nativeToRecords(n livedns.DomainRecord, origin string) (rcs []*models.RecordConfig) {
+func nativeToRecord(n livedns.DomainRecord, origin string) (rcs []*models.RecordConfig, err error) {
 
 	// Gandi returns all the values for a given label/rtype pair in each
 	// livedns.DomainRecord.  In other words, if there are multiple A
 	// records for a label, all the IP addresses are listed in
 	// n.RrsetValues rather than having many livedns.DomainRecord's.
 	// We must split them out into individual records, one for each value.
+	// Each of them keeps the whole rrset as Original, including its
+	// RrsetHref, so the rrset can be identified again later on.
 	for _, value := range n.RrsetValues {
 		rc := &models.RecordConfig{
 			TTL:      uint32(n.RrsetTTL),
@@ -31,13 +54,43 @@ func nativeToRecords(n livedns.DomainRecord, origin string) (rcs []*models.Recor
 			rc.SetTarget(value)
 		default: //  "A", "AAAA", "CAA", "NS", "CNAME", "MX", "PTR", "SRV", "TXT"
 			if err := rc.PopulateFromString(rtype, value, origin); err != nil {
-				panic(fmt.Errorf("unparsable record received from gandi: %w", err))
+				return nil, fmt.Errorf("unparsable record received from gandi: %w", err)
+			}
+			if rtype == "SRV" {
+				// Gandi may return the target in another case or relative
+				// to the zone.
+				rc.SetTarget(strings.ToLower(dnsutil.AddOrigin(rc.GetTargetField(), origin+".")))
 			}
 		}
 		rcs = append(rcs, rc)
 	}
 
-	return rcs
+	return rcs, nil
+}
+
+// neutralRecords returns copies of records read from Gandi in the form
+// any other provider would return them, so they can be imported into
+// another provider: hostnames are fully qualified, the records are
+// downcased like PostProcessRecords does and Gandi's record is dropped.
+func neutralRecords(recs models.Records, origin string) (models.Records, error) {
+	neutral := make(models.Records, 0, len(recs))
+	for _, r := range recs {
+		// Copy() can't serialize the Gandi record, drop it first.
+		shallow := *r
+		shallow.Original = nil
+		rc, err := shallow.Copy()
+		if err != nil {
+			return nil, err
+		}
+		switch rc.Type { // #rtype_variations
+		case "ALIAS", "CNAME", "MX", "NS", "PTR", "SRV":
+			// Gandi may return in-zone hostnames relative to the zone.
+			rc.SetTarget(dnsutil.AddOrigin(rc.GetTargetField(), origin+"."))
+		}
+		neutral = append(neutral, rc)
+	}
+	models.PostProcessRecords(neutral)
+	return neutral, nil
 }
 
 func recordsToNative(rcs []*models.RecordConfig, origin string) []livedns.DomainRecord {
@@ -47,6 +100,9 @@ func recordsToNative(rcs []*models.RecordConfig, origin string) []livedns.Domain
 
 	var keys = map[models.RecordKey]*livedns.DomainRecord{}
 	var zrs []livedns.DomainRecord
+	// The values and TTLs of each rrset, to tell which ones conflict.
+	var members = map[models.RecordKey][]string{}
+	var ttls = map[models.RecordKey][]uint32{}
 
 	for _, r := range rcs {
 		label := r.GetLabel()
@@ -54,31 +110,90 @@ func recordsToNative(rcs []*models.RecordConfig, origin string) []livedns.Domain
 			label = origin
 		}
 		key := r.Key()
+		value := recordValueToNative(r)
+		ttl := r.TTL
+		if ttl < minTTL {
+			// PrepDesiredRecords normally takes care of this. The API
+			// rejects such TTLs with an unhelpful error, so be safe.
+			printer.Warnf("Gandi does not support ttls < %d. Setting %s from %d to %d\n", minTTL, r.GetLabelFQDN(), ttl, minTTL)
+			ttl = minTTL
+		}
+
+		members[key] = append(members[key], value)
+		ttls[key] = append(ttls[key], ttl)
 
 		if zr, ok := keys[key]; !ok {
 			// Allocate a new ZoneRecord:
 			zr := livedns.DomainRecord{
 				RrsetType:   r.Type,
-				RrsetTTL:    int(r.TTL),
+				RrsetTTL:    int(ttl),
 				RrsetName:   label,
-				RrsetValues: []string{r.GetTargetCombined()},
+				RrsetValues: []string{value},
 			}
 			zrs = append(zrs, zr)
 			//keys[key] = &zr   // This didn't work.
 			keys[key] = &zrs[len(zrs)-1] // This does work. I don't know why.
 
 		} else {
-			zr.RrsetValues = append(zr.RrsetValues, r.GetTargetCombined())
+			zr.RrsetValues = append(zr.RrsetValues, value)
 
-			if r.TTL != uint32(zr.RrsetTTL) {
-				printer.Warnf("All TTLs for a rrset (%v) must be the same. Using smaller of %v and %v.\n", key, r.TTL, zr.RrsetTTL)
-				if r.TTL < uint32(zr.RrsetTTL) {
-					zr.RrsetTTL = int(r.TTL)
-				}
+			if ttl < uint32(zr.RrsetTTL) {
+				zr.RrsetTTL = int(ttl)
 			}
 
 		}
 	}
 
+	for _, r := range rcs {
+		key := r.Key()
+		if _, ok := ttls[key]; ok {
+			warnConflictingTTLs(key, members[key], ttls[key], uint32(keys[key].RrsetTTL))
+			delete(ttls, key) // Warn once per rrset.
+		}
+	}
+
+	return zrs
+}
+
+// omitDefaultTTL clears the TTL of the rrsets whose TTL is defaultTTL, so
+// that it is left out of the payload and Gandi applies its default.
+func omitDefaultTTL(zrs []livedns.DomainRecord, defaultTTL int) []livedns.DomainRecord {
+	if defaultTTL == 0 {
+		return zrs
+	}
+	for i := range zrs {
+		if zrs[i].RrsetTTL == defaultTTL {
+			zrs[i].RrsetTTL = 0
+		}
+	}
 	return zrs
 }
+
+// warnConflictingTTLs warns if the values of a rrset have different TTLs,
+// naming each value whose TTL differs from the ttl that is used.
+func warnConflictingTTLs(key models.RecordKey, values []string, ttls []uint32, ttl uint32) {
+	var conflicting []string
+	for i, value := range values {
+		if ttls[i] != ttl {
+			conflicting = append(conflicting, fmt.Sprintf("%s (ttl=%d)", value, ttls[i]))
+		}
+	}
+	if len(conflicting) == 0 {
+		return
+	}
+	printer.Warnf("All TTLs for a rrset (%v) must be the same. %d of %d records disagree: %s. Using the smallest, %d.\n",
+		key, len(conflicting), len(values), strings.Join(conflicting, ", "), ttl)
+}
+
+// recordValueToNative returns the value of a RecordConfig the way Gandi
+// expects it in RrsetValues.
+func recordValueToNative(r *models.RecordConfig) string {
+	switch r.Type {
+	case "ALIAS":
+		// ALIAS is not a real rtype. Send the target exactly as Gandi
+		// gave it to us; it must never be quoted.
+		return r.GetTargetField()
+	default:
+		return r.GetTargetCombined()
+	}
+}
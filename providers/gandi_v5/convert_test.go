@@ -1,9 +1,16 @@
 package gandi5
 
 import (
+	"bytes"
+	"encoding/json"
+	"reflect"
 	"testing"
 
+	"github.com/go-gandi/go-gandi/livedns"
+
 	"github.com/StackExchange/dnscontrol/v3/models"
+	"github.com/StackExchange/dnscontrol/v3/pkg/diff"
+	"github.com/StackExchange/dnscontrol/v3/pkg/printer"
 )
 
 func TestRecordsToNative_1(t *testing.T) {
@@ -42,3 +49,280 @@ func TestRecordsToNative_2(t *testing.T) {
 	}
 
 }
+
+func TestRecordsToNative_alias(t *testing.T) {
+	n := livedns.DomainRecord{
+		RrsetType:   "ALIAS",
+		RrsetTTL:    300,
+		RrsetName:   "@",
+		RrsetHref:   "https://api.gandi.net/v5/livedns/domains/example.com/records/%40/ALIAS",
+		RrsetValues: []string{"target.example.net."},
+	}
+
+	rcs, err := nativeToRecord(n, "example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rcs) != 1 {
+		t.Fatalf("len(rcs) != 1; got=%v", len(rcs))
+	}
+	if orig := rcs[0].Original.(livedns.DomainRecord); orig.RrsetHref != n.RrsetHref {
+		t.Errorf("href not preserved; got=%q", orig.RrsetHref)
+	}
+
+	ns := recordsToNative(rcs, "example.com")
+	if len(ns) != 1 {
+		t.Fatalf("len(ns) != 1; got=%v", len(ns))
+	}
+	if len(ns[0].RrsetValues) != 1 || ns[0].RrsetValues[0] != n.RrsetValues[0] {
+		t.Errorf("ALIAS value changed in round-trip; want=%q got=%q", n.RrsetValues, ns[0].RrsetValues)
+	}
+}
+
+func TestNativeRoundTrip(t *testing.T) {
+	// Each supported type must be sent back exactly as Gandi returned it.
+	for _, test := range []struct{ rtype, name, value string }{
+		{"A", "www", "192.0.2.1"},
+		{"AAAA", "www", "2001:db8::1"},
+		{"MX", "@", "10 mail.example.com."},
+		{"CNAME", "www", "web.example.net."},
+		{"CNAME", "www", "web"},
+		{"TXT", "@", `"v=spf1 -all"`},
+		{"TXT", "@", `"first" "second"`},
+		{"TXT", "@", `"say \"hi\""`},
+		{"SRV", "_sip._tcp", "10 60 5060 sip.example.com."},
+		{"CAA", "@", `0 issue "letsencrypt.org"`},
+		{"CAA", "@", `128 iodef "mailto:security@example.com"`},
+		{"NS", "sub", "ns1.example.net."},
+		{"ALIAS", "@", "lb.example.net."},
+	} {
+		n := livedns.DomainRecord{RrsetType: test.rtype, RrsetName: test.name, RrsetTTL: 300, RrsetValues: []string{test.value}}
+		rcs, err := nativeToRecords([]livedns.DomainRecord{n}, "example.com", false)
+		if err != nil {
+			t.Errorf("%s %s: %v", test.rtype, test.value, err)
+			continue
+		}
+		ns := recordsToNative(rcs, "example.com")
+		if len(ns) != 1 || len(ns[0].RrsetValues) != 1 {
+			t.Errorf("%s %s: expected a single value; got=%+v", test.rtype, test.value, ns)
+			continue
+		}
+		if got := ns[0]; got.RrsetType != test.rtype || got.RrsetTTL != 300 || got.RrsetValues[0] != test.value {
+			t.Errorf("%s %s: changed in round-trip; got=%s %d %s", test.rtype, test.value, got.RrsetType, got.RrsetTTL, got.RrsetValues[0])
+		}
+	}
+}
+
+func TestSRVRoundTrip(t *testing.T) {
+	config := &models.RecordConfig{Type: "SRV", TTL: 300}
+	config.SetLabel("_sip._tcp", "example.com")
+	if err := config.SetTargetSRV(10, 60, 5060, "sip.example.com."); err != nil {
+		t.Fatal(err)
+	}
+
+	ns := recordsToNative([]*models.RecordConfig{config}, "example.com")
+	if len(ns) != 1 || ns[0].RrsetValues[0] != "10 60 5060 sip.example.com." {
+		t.Fatalf("unexpected value sent to Gandi: %+v", ns)
+	}
+	for _, value := range []string{ns[0].RrsetValues[0], "10 60 5060 sip", "10 60 5060 SIP.example.com."} {
+		n := ns[0]
+		n.RrsetValues = []string{value}
+		rcs, err := nativeToRecords([]livedns.DomainRecord{n}, "example.com", false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := rcs[0].GetTargetCombined(), config.GetTargetCombined(); got != want {
+			t.Errorf("%q: expected %q; got=%q", value, want, got)
+		}
+	}
+}
+
+func TestRecordsToNative_minTTL(t *testing.T) {
+	var rcs = []*models.RecordConfig{{}}
+	rcs[0].SetLabelFromFQDN("foo.example.com", "example.com")
+	rcs[0].Type = "A"
+	rcs[0].TTL = 60
+	rcs[0].SetTarget("1.2.3.4")
+
+	ns := recordsToNative(rcs, "example.com")
+
+	if len(ns) != 1 {
+		t.Fatalf("len(ns) != 1; got=%v", len(ns))
+	}
+	if ns[0].RrsetTTL != 300 {
+		t.Errorf("ns[0].RrsetTTL != 300; got=%v", ns[0].RrsetTTL)
+	}
+}
+
+func TestOmitDefaultTTL(t *testing.T) {
+	rcs := []*models.RecordConfig{
+		{Type: "A", Name: "www", NameFQDN: "www.example.com", Target: "1.2.3.4", TTL: 10800},
+		{Type: "A", Name: "mail", NameFQDN: "mail.example.com", Target: "1.2.3.5", TTL: 300},
+	}
+
+	payload, err := json.Marshal(omitDefaultTTL(recordsToNative(rcs, "example.com"), 10800))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sent []map[string]interface{}
+	if err := json.Unmarshal(payload, &sent); err != nil {
+		t.Fatal(err)
+	}
+	if len(sent) != 2 {
+		t.Fatalf("expected 2 rrsets; got=%s", payload)
+	}
+	if _, ok := sent[0]["rrset_ttl"]; ok {
+		t.Errorf("expected the default TTL to be omitted; got=%s", payload)
+	}
+	if ttl, ok := sent[1]["rrset_ttl"]; !ok || ttl != float64(300) {
+		t.Errorf("expected other TTLs to be sent; got=%s", payload)
+	}
+
+	// Without a default, all TTLs are sent.
+	ns := omitDefaultTTL(recordsToNative(rcs, "example.com"), 0)
+	if ns[0].RrsetTTL != 10800 || ns[1].RrsetTTL != 300 {
+		t.Errorf("expected all TTLs to be kept; got=%+v", ns)
+	}
+}
+
+func TestRecordsToNative_conflictingTTLs(t *testing.T) {
+	var rcs models.Records
+	for _, r := range []struct {
+		ip  string
+		ttl uint32
+	}{
+		{"1.2.3.4", 3600},
+		{"5.6.7.8", 600},
+		{"9.10.11.12", 3600},
+	} {
+		rc := &models.RecordConfig{Type: "A", TTL: r.ttl}
+		rc.SetLabelFromFQDN("www.example.com", "example.com")
+		rc.SetTarget(r.ip)
+		rcs = append(rcs, rc)
+	}
+
+	var out bytes.Buffer
+	old := printer.DefaultPrinter
+	printer.DefaultPrinter = &printer.ConsolePrinter{Writer: &out}
+	defer func() { printer.DefaultPrinter = old }()
+
+	ns := recordsToNative(rcs, "example.com")
+	if len(ns) != 1 || ns[0].RrsetTTL != 600 {
+		t.Fatalf("expected one rrset with the smallest TTL; got=%+v", ns)
+	}
+	want := "WARNING: All TTLs for a rrset ({www.example.com A}) must be the same. 2 of 3 records disagree: 1.2.3.4 (ttl=3600), 9.10.11.12 (ttl=3600). Using the smallest, 600.\n"
+	if out.String() != want {
+		t.Errorf("expected warning %q; got=%q", want, out.String())
+	}
+}
+
+func TestNativeToRecords_unknownType(t *testing.T) {
+	ns := []livedns.DomainRecord{
+		{RrsetType: "A", RrsetTTL: 300, RrsetName: "www", RrsetValues: []string{"1.2.3.4"}},
+		{RrsetType: "WKS", RrsetTTL: 300, RrsetName: "www", RrsetValues: []string{"1.2.3.4 6 25"}},
+		{RrsetType: "MX", RrsetTTL: 300, RrsetName: "@", RrsetValues: []string{"10 mx.example.com."}},
+	}
+
+	if _, err := nativeToRecords(ns, "example.com", false); err == nil {
+		t.Errorf("expected an error for an unknown type")
+	}
+
+	rcs, err := nativeToRecords(ns, "example.com", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rcs) != 2 || rcs[0].Type != "A" || rcs[1].Type != "MX" {
+		t.Errorf("expected the A and MX records to be kept; got=%v", rcs)
+	}
+}
+
+func TestNativeToRecords_multiValue(t *testing.T) {
+	ns := []livedns.DomainRecord{
+		{RrsetType: "A", RrsetTTL: 300, RrsetName: "www", RrsetValues: []string{"1.2.3.4", "5.6.7.8", "9.10.11.12"},
+			RrsetHref: "https://api.gandi.net/v5/livedns/domains/example.com/records/www/A"},
+		{RrsetType: "MX", RrsetTTL: 3600, RrsetName: "@", RrsetValues: []string{"10 mx1.example.com.", "20 mx2.example.com."},
+			RrsetHref: "https://api.gandi.net/v5/livedns/domains/example.com/records/%40/MX"},
+	}
+
+	rcs, err := nativeToRecords(ns, "example.com", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rcs) != 5 {
+		t.Fatalf("expected one record per value; got=%d", len(rcs))
+	}
+	for i, want := range []struct {
+		native int
+		target string
+	}{
+		{0, "1.2.3.4"},
+		{0, "5.6.7.8"},
+		{0, "9.10.11.12"},
+		{1, "mx1.example.com."},
+		{1, "mx2.example.com."},
+	} {
+		rc, n := rcs[i], ns[want.native]
+		if rc.Type != n.RrsetType || rc.GetTargetField() != want.target {
+			t.Errorf("%d: expected %s %s; got=%s %s", i, n.RrsetType, want.target, rc.Type, rc.GetTargetField())
+		}
+		if rc.TTL != uint32(n.RrsetTTL) {
+			t.Errorf("%d: expected the TTL of the rrset %d; got=%d", i, n.RrsetTTL, rc.TTL)
+		}
+		if !reflect.DeepEqual(rc.Original, n) {
+			t.Errorf("%d: expected the rrset as Original; got=%v", i, rc.Original)
+		}
+		if href := rc.Original.(livedns.DomainRecord).RrsetHref; href != n.RrsetHref {
+			t.Errorf("%d: expected the href of the rrset %q; got=%q", i, n.RrsetHref, href)
+		}
+	}
+}
+
+func TestNeutralRecords(t *testing.T) {
+	ns := []livedns.DomainRecord{
+		{RrsetType: "TXT", RrsetTTL: 300, RrsetName: "@", RrsetValues: []string{`"v=spf1 -all"`, `"part one" "part two"`}},
+		{RrsetType: "CNAME", RrsetTTL: 300, RrsetName: "www", RrsetValues: []string{"web"}},
+		{RrsetType: "CNAME", RrsetTTL: 300, RrsetName: "ext", RrsetValues: []string{"Host.Example.NET."}},
+		{RrsetType: "MX", RrsetTTL: 300, RrsetName: "@", RrsetValues: []string{"10 mail"}},
+		{RrsetType: "A", RrsetTTL: 300, RrsetName: "web", RrsetValues: []string{"1.2.3.4"}},
+	}
+	found, err := nativeToRecords(ns, "example.com", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exported, err := neutralRecords(found, "example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, rc := range exported {
+		if rc.Original != nil {
+			t.Errorf("%s %s: Gandi record was not dropped", rc.Type, rc.GetLabel())
+		}
+	}
+
+	// The same zone, the way another provider (e.g. HETZNER) reads it.
+	want := models.Records{}
+	for _, r := range []struct{ label, rtype, value string }{
+		{"@", "TXT", `v=spf1 -all`},
+		{"@", "TXT", `"part one" "part two"`},
+		{"www", "CNAME", "web.example.com."},
+		{"ext", "CNAME", "host.example.net."},
+		{"@", "MX", "10 mail.example.com."},
+		{"web", "A", "1.2.3.4"},
+	} {
+		rc := &models.RecordConfig{TTL: 300}
+		rc.SetLabel(r.label, "example.com")
+		if err := rc.PopulateFromString(r.rtype, r.value, "example.com"); err != nil {
+			t.Fatal(err)
+		}
+		want = append(want, rc)
+	}
+
+	dc := &models.DomainConfig{Name: "example.com", Records: want}
+	_, create, del, mod, err := diff.New(dc).IncrementalDiff(exported)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range append(append(create, del...), mod...) {
+		t.Errorf("export does not re-import cleanly: %s", c)
+	}
+}
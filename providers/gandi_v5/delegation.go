@@ -0,0 +1,88 @@
+package gandi5
+
+// Awareness of subzone delegations (NS records below the apex).
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v3/models"
+	"github.com/StackExchange/dnscontrol/v3/pkg/printer"
+)
+
+// delegatedLabels returns the FQDNs of all labels below the apex that
+// are delegated to other nameservers, mapped to their NS targets.
+func delegatedLabels(dc *models.DomainConfig) map[string][]string {
+	delegations := map[string][]string{}
+	for _, rec := range dc.Records {
+		if rec.Type != "NS" || rec.GetLabel() == "@" {
+			continue
+		}
+		label := rec.GetLabelFQDN()
+		delegations[label] = append(delegations[label], strings.TrimSuffix(rec.GetTargetField(), "."))
+	}
+	return delegations
+}
+
+// delegationFor returns the delegated label that fqdn is at or below,
+// or "" if fqdn is not inside a delegated subzone.
+func delegationFor(fqdn string, delegations map[string][]string) string {
+	for label := range delegations {
+		if fqdn == label || strings.HasSuffix(fqdn, "."+label) {
+			return label
+		}
+	}
+	return ""
+}
+
+// warnDelegations warns about records that depend on a delegation.
+// Records below a delegated label (other than glue for the delegated
+// nameservers) are hidden by the delegation and belong in the subzone.
+// New delegations are flagged because the subzone, usually hosted by
+// another provider, must be populated before it starts receiving queries.
+func warnDelegations(dc *models.DomainConfig, delegations map[string][]string, doesLabelExist map[string]bool) {
+	labels := make([]string, 0, len(delegations))
+	for label := range delegations {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+	for _, label := range labels {
+		if !doesLabelExist[label] {
+			printer.Warnf("Gandi: %s will be delegated to %s. Make sure that zone is populated before (or as part of) this push.\n", label, strings.Join(delegations[label], ", "))
+		}
+	}
+
+	for _, rec := range dc.Records {
+		fqdn := rec.GetLabelFQDN()
+		label := delegationFor(fqdn, delegations)
+		if label == "" || (rec.Type == "NS" && fqdn == label) {
+			continue
+		}
+		if isGlue(rec, delegations[label]) {
+			continue
+		}
+		printer.Warnf("Gandi: %s %s is inside the delegated subzone %s and will not be visible. Manage it in the %s zone instead.\n", rec.Type, fqdn, label, label)
+	}
+}
+
+// isGlue reports whether rec is an address record for one of the nameservers.
+func isGlue(rec *models.RecordConfig, nameservers []string) bool {
+	if rec.Type != "A" && rec.Type != "AAAA" {
+		return false
+	}
+	for _, ns := range nameservers {
+		if rec.GetLabelFQDN() == ns {
+			return true
+		}
+	}
+	return false
+}
+
+// orderDelegationsFirst moves the corrections that touch a delegation to the
+// front of the list, keeping the existing order otherwise.  Delegations
+// must be in place before records in the subzone are expected to resolve.
+func orderDelegationsFirst(corrections []*models.Correction, isDelegation map[*models.Correction]bool) {
+	sort.SliceStable(corrections, func(i, j int) bool {
+		return isDelegation[corrections[i]] && !isDelegation[corrections[j]]
+	})
+}
@@ -0,0 +1,68 @@
+package gandi5
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/v3/models"
+)
+
+func makeRC(label, rtype, target string) *models.RecordConfig {
+	rc := &models.RecordConfig{Type: rtype, TTL: 300}
+	rc.SetLabel(label, "example.com")
+	rc.SetTarget(target)
+	return rc
+}
+
+func TestDelegatedLabels(t *testing.T) {
+	dc := &models.DomainConfig{
+		Name: "example.com",
+		Records: models.Records{
+			makeRC("@", "NS", "ns1.gandi.net."),
+			makeRC("sub", "NS", "ns1.other.net."),
+			makeRC("sub", "NS", "ns2.other.net."),
+			makeRC("www", "A", "1.2.3.4"),
+		},
+	}
+
+	d := delegatedLabels(dc)
+	if len(d) != 1 {
+		t.Fatalf("expected 1 delegation; got=%v", d)
+	}
+	if ns := d["sub.example.com"]; len(ns) != 2 || ns[0] != "ns1.other.net" {
+		t.Errorf("unexpected nameservers for sub.example.com; got=%v", ns)
+	}
+	if got := delegationFor("host.sub.example.com", d); got != "sub.example.com" {
+		t.Errorf("host.sub.example.com should be inside sub.example.com; got=%q", got)
+	}
+	if got := delegationFor("notsub.example.com", d); got != "" {
+		t.Errorf("notsub.example.com should not be delegated; got=%q", got)
+	}
+}
+
+func TestGenerateDomainCorrections_delegationFirst(t *testing.T) {
+	dc := &models.DomainConfig{
+		Name: "example.com",
+		Records: models.Records{
+			makeRC("aaa", "A", "1.2.3.4"),
+			makeRC("sub", "NS", "ns1.other.net."),
+			makeRC("sub", "NS", "ns2.other.net."),
+			makeRC("www", "A", "5.6.7.8"),
+		},
+	}
+	client := &gandiv5Provider{apikey: "test"}
+
+	corrections, err := client.GenerateDomainCorrections(dc, models.Records{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(corrections) != 3 {
+		t.Fatalf("expected 3 corrections; got=%d", len(corrections))
+	}
+	if !strings.Contains(corrections[0].Msg, "NS sub.example.com") {
+		t.Errorf("delegation should be applied first; got=%q", corrections[0].Msg)
+	}
+	if !strings.Contains(corrections[1].Msg, "aaa.example.com") || !strings.Contains(corrections[2].Msg, "www.example.com") {
+		t.Errorf("remaining corrections should keep their order; got=%q, %q", corrections[1].Msg, corrections[2].Msg)
+	}
+}
@@ -10,6 +10,11 @@ Endpoint: https://api.gandi.net/
 Settings from `creds.json`:
    - apikey
    - sharing_id (optional)
+   - check_frozen (optional)
+   - skip_unknown_types (optional)
+   - timeout (optional)
+   - retries (optional)
+   - max_retries (optional)
 
 */
 
@@ -22,11 +27,14 @@ import (
 	"strings"
 
 	gandi "github.com/go-gandi/go-gandi"
+	"github.com/go-gandi/go-gandi/domain"
+	"github.com/go-gandi/go-gandi/livedns"
 	"github.com/miekg/dns/dnsutil"
 
 	"github.com/StackExchange/dnscontrol/v3/models"
 	"github.com/StackExchange/dnscontrol/v3/pkg/diff"
 	"github.com/StackExchange/dnscontrol/v3/pkg/printer"
+	"github.com/StackExchange/dnscontrol/v3/pkg/redact"
 	"github.com/StackExchange/dnscontrol/v3/providers"
 )
 
@@ -53,6 +61,12 @@ var features = providers.DocumentationNotes{
 	providers.CanGetZones:            providers.Can(),
 }
 
+// Gandi rejects TTLs outside of this range.
+const (
+	minTTL = 300
+	maxTTL = 2592000 // 30 days
+)
+
 // DNSSEC: platform supports it, but it doesn't fit our GetDomainCorrections
 // model, so deferring for now.
 
@@ -60,9 +74,17 @@ var features = providers.DocumentationNotes{
 
 // gandiv5Provider is the gandiv5Provider handle used to store any client-related state.
 type gandiv5Provider struct {
-	apikey    string
-	sharingid string
-	debug     bool
+	apikey           string
+	sharingid        string
+	debug            bool
+	checkFrozen      bool
+	skipUnknownTypes bool
+	defaultTTL       int // 0 means TTLs are always sent.
+	options          apiOptions
+	domainOptions    map[string]apiOptions
+
+	// fakeLiveDNS replaces the LiveDNS API, for testing.
+	fakeLiveDNS liveDNS
 }
 
 // newDsp generates a DNS Service Provider client handle.
@@ -87,6 +109,32 @@ func newHelper(m map[string]string, metadata json.RawMessage) (*gandiv5Provider,
 	if err == nil {
 		api.debug = debug
 	}
+	if v := m["check_frozen"]; v != "" {
+		api.checkFrozen, err = strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Gandi check_frozen %q: %w", v, err)
+		}
+	}
+	if v := m["skip_unknown_types"]; v != "" {
+		api.skipUnknownTypes, err = strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Gandi skip_unknown_types %q: %w", v, err)
+		}
+	}
+	if v := m["default_ttl"]; v != "" {
+		api.defaultTTL, err = strconv.Atoi(v)
+		if err != nil || api.defaultTTL < minTTL || api.defaultTTL > maxTTL {
+			return nil, fmt.Errorf("invalid Gandi default_ttl %q", v)
+		}
+	}
+	api.options, err = parseAPIOptions(m)
+	if err != nil {
+		return nil, err
+	}
+	api.domainOptions, err = parseDomainOptions(metadata, api.options)
+	if err != nil {
+		return nil, err
+	}
 
 	return api, nil
 }
@@ -106,6 +154,19 @@ func newHelper(m map[string]string, metadata json.RawMessage) (*gandiv5Provider,
 // GetDomainCorrections get the current and existing records,
 // post-process them, and generate corrections.
 func (client *gandiv5Provider) GetDomainCorrections(dc *models.DomainConfig) ([]*models.Correction, error) {
+	if client.checkFrozen {
+		gd := gandi.NewDomainClient(client.apikey, gandi.Config{SharingID: client.sharingid, Debug: client.debug})
+		details, err := client.read(dc.Name, func() (interface{}, error) {
+			return gd.GetDomain(dc.Name)
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err := checkDomainNotFrozen(dc.Name, details.(domain.Details).Status); err != nil {
+			return nil, err
+		}
+	}
+
 	existing, err := client.GetZoneRecords(dc.Name)
 	if err != nil {
 		return nil, err
@@ -119,21 +180,38 @@ func (client *gandiv5Provider) GetDomainCorrections(dc *models.DomainConfig) ([]
 // GetZoneRecords gathers the DNS records and converts them to
 // dnscontrol's format.
 func (client *gandiv5Provider) GetZoneRecords(domain string) (models.Records, error) {
-	g := gandi.NewLiveDNSClient(client.apikey, gandi.Config{SharingID: client.sharingid, Debug: client.debug})
-
-	// Get all the existing records:
-	records, err := g.GetDomainRecords(domain)
-	if err != nil {
-		return nil, err
+	g := client.liveDNS()
+
+	// Get all the existing records, page by page:
+	var records []livedns.DomainRecord
+	for page, more := 1, true; more; page++ {
+		type result struct {
+			records []livedns.DomainRecord
+			more    bool
+		}
+		r, err := client.read(domain, func() (interface{}, error) {
+			recs, next, err := g.getDomainRecords(domain, page)
+			return result{recs, next}, err
+		})
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, r.(result).records...)
+		more = r.(result).more && len(r.(result).records) > 0
 	}
 
 	// Convert them to DNScontrol's native format:
-	existingRecords := []*models.RecordConfig{}
-	for _, rr := range records {
-		existingRecords = append(existingRecords, nativeToRecords(rr, domain)...)
-	}
+	return nativeToRecords(records, domain, client.skipUnknownTypes)
+}
 
-	return existingRecords, nil
+// ExportZoneRecords returns the records of a zone in a provider-neutral
+// form, for migrating the zone to another provider.
+func (client *gandiv5Provider) ExportZoneRecords(domain string) (models.Records, error) {
+	records, err := client.GetZoneRecords(domain)
+	if err != nil {
+		return nil, err
+	}
+	return neutralRecords(records, domain)
 }
 
 // PrepFoundRecords munges any records to make them compatible with
@@ -158,15 +236,16 @@ func PrepDesiredRecords(dc *models.DomainConfig) {
 		if rec.Type == "ALIAS" && rec.Name != "@" {
 			// GANDI only permits aliases on a naked domain.
 			// Therefore, we change this to a CNAME.
+			printer.Warnf("Gandi only permits ALIAS records on the bare domain. Changing %s to a CNAME, use CNAME in dnsconfig.js to silence this warning\n", rec.GetLabelFQDN())
 			rec.Type = "CNAME"
 		}
-		if rec.TTL < 300 {
-			printer.Warnf("Gandi does not support ttls < 300. Setting %s from %d to 300\n", rec.GetLabelFQDN(), rec.TTL)
-			rec.TTL = 300
+		if rec.TTL < minTTL {
+			printer.Warnf("Gandi does not support ttls < %d. Setting %s from %d to %d\n", minTTL, rec.GetLabelFQDN(), rec.TTL, minTTL)
+			rec.TTL = minTTL
 		}
-		if rec.TTL > 2592000 {
-			printer.Warnf("Gandi does not support ttls > 30 days. Setting %s from %d to 2592000\n", rec.GetLabelFQDN(), rec.TTL)
-			rec.TTL = 2592000
+		if rec.TTL > maxTTL {
+			printer.Warnf("Gandi does not support ttls > 30 days. Setting %s from %d to %d\n", rec.GetLabelFQDN(), rec.TTL, maxTTL)
+			rec.TTL = maxTTL
 		}
 		if rec.Type == "TXT" {
 			rec.SetTarget("\"" + rec.GetTargetField() + "\"") // FIXME(tlim): Should do proper quoting.
@@ -192,6 +271,10 @@ func (client *gandiv5Provider) GenerateDomainCorrections(dc *models.DomainConfig
 		debugRecords("GenDC input", existing)
 	}
 
+	if err := checkAliases(dc); err != nil {
+		return nil, err
+	}
+
 	var corrections = []*models.Correction{}
 
 	// diff existing vs. current.
@@ -211,11 +294,15 @@ func (client *gandiv5Provider) GenerateDomainCorrections(dc *models.DomainConfig
 	affectedLabels, msgsForLabel := gatherAffectedLabels(keysToUpdate)
 	_, desiredRecords := dc.Records.GroupedByFQDN()
 	doesLabelExist := existing.FQDNMap()
+	delegations := delegatedLabels(dc)
+	warnDelegations(dc, delegations, doesLabelExist)
+	isDelegation := map[*models.Correction]bool{}
 
-	g := gandi.NewLiveDNSClient(client.apikey, gandi.Config{SharingID: client.sharingid, Debug: client.debug})
+	g := client.liveDNS()
 
 	// For any key with an update, delete or replace those records.
 	for label := range affectedLabels {
+		first := len(corrections)
 		if len(desiredRecords[label]) == 0 {
 			// No records matching this key?  This can only mean that all
 			// the records were deleted. Delete them.
@@ -227,11 +314,9 @@ func (client *gandiv5Provider) GenerateDomainCorrections(dc *models.DomainConfig
 				&models.Correction{
 					Msg: msgs,
 					F: func() error {
-						err := g.DeleteDomainRecordsByName(domain, shortname)
-						if err != nil {
-							return err
-						}
-						return nil
+						return client.write(domain, func() error {
+							return g.deleteDomainRecordsByName(domain, shortname)
+						})
 					},
 				})
 
@@ -239,7 +324,7 @@ func (client *gandiv5Provider) GenerateDomainCorrections(dc *models.DomainConfig
 			// Replace all the records at a label with our new records.
 
 			// Generate the new data in Gandi's format.
-			ns := recordsToNative(desiredRecords[label], dc.Name)
+			ns := omitDefaultTTL(recordsToNative(desiredRecords[label], dc.Name), client.defaultTTL)
 
 			if doesLabelExist[label] {
 				// Records exist for this label. Replace them with what we have.
@@ -251,11 +336,9 @@ func (client *gandiv5Provider) GenerateDomainCorrections(dc *models.DomainConfig
 					&models.Correction{
 						Msg: msg,
 						F: func() error {
-							res, err := g.UpdateDomainRecordsByName(domain, shortname, ns)
-							if err != nil {
-								return fmt.Errorf("%+v: %w", res, err)
-							}
-							return nil
+							return client.write(domain, func() error {
+								return g.updateDomainRecordsByName(domain, shortname, ns)
+							})
 						},
 					})
 
@@ -274,16 +357,19 @@ func (client *gandiv5Provider) GenerateDomainCorrections(dc *models.DomainConfig
 						&models.Correction{
 							Msg: msg,
 							F: func() error {
-								res, err := g.CreateDomainRecord(domain, shortname, rtype, ttl, values)
-								if err != nil {
-									return fmt.Errorf("%+v: %w", res, err)
-								}
-								return nil
+								return client.write(domain, func() error {
+									return g.createDomainRecord(domain, shortname, rtype, ttl, values)
+								})
 							},
 						})
 				}
 			}
 		}
+		if _, ok := delegations[label]; ok {
+			for _, c := range corrections[first:] {
+				isDelegation[c] = true
+			}
+		}
 	}
 
 	// NB(tlim): This sort is just to make updates look pretty. It is
@@ -293,10 +379,53 @@ func (client *gandiv5Provider) GenerateDomainCorrections(dc *models.DomainConfig
 	// pass.  That said, if this breaks anything, the easiest fix might
 	// be to just remove the sort.
 	sort.Slice(corrections, func(i, j int) bool { return diff.CorrectionLess(corrections, i, j) })
+	orderDelegationsFirst(corrections, isDelegation)
 
 	return corrections, nil
 }
 
+// frozenStatuses are the domain statuses in which Gandi refuses changes.
+var frozenStatuses = map[string]bool{
+	"clientHold":             true,
+	"clientUpdateProhibited": true,
+	"pendingDelete":          true,
+	"redemptionPeriod":       true,
+	"serverHold":             true,
+	"serverUpdateProhibited": true,
+}
+
+// checkAliases returns an error for ALIAS records that Gandi would reject.
+// PrepDesiredRecords turns them into CNAMEs, but GenerateDomainCorrections
+// may be called without it.
+func checkAliases(dc *models.DomainConfig) error {
+	for _, rec := range dc.Records {
+		if rec.Type == "ALIAS" && rec.GetLabel() != "@" {
+			return fmt.Errorf("Gandi only permits ALIAS records on the bare domain %s, not on %s; use a CNAME instead", dc.Name, rec.GetLabelFQDN())
+		}
+	}
+	return nil
+}
+
+// checkDomainNotFrozen returns an error if any of the statuses
+// reported by the domain API prevents the domain from being changed.
+func checkDomainNotFrozen(domain string, statuses []string) error {
+	var frozen []string
+	for _, status := range statuses {
+		if frozenStatuses[status] {
+			frozen = append(frozen, status)
+		}
+	}
+	if len(frozen) > 0 {
+		return fmt.Errorf("domain %s is frozen at Gandi (status: %s); no changes can be made until it is unlocked", domain, strings.Join(frozen, ", "))
+	}
+	return nil
+}
+
+// redact removes the credentials from errors returned by the Gandi client.
+func (client *gandiv5Provider) redact(err error) error {
+	return redact.Error(err, client.apikey, client.sharingid)
+}
+
 // debugRecords prints a list of RecordConfig.
 func debugRecords(note string, recs []*models.RecordConfig) {
 	fmt.Println("DEBUG:", note)
@@ -322,22 +451,27 @@ func gatherAffectedLabels(groups map[models.RecordKey][]string) (labels map[stri
 
 // GetNameservers returns a list of nameservers for domain.
 func (client *gandiv5Provider) GetNameservers(domain string) ([]*models.Nameserver, error) {
-	g := gandi.NewLiveDNSClient(client.apikey, gandi.Config{SharingID: client.sharingid, Debug: client.debug})
-	nameservers, err := g.GetDomainNS(domain)
+	g := client.liveDNS()
+	nameservers, err := client.read(domain, func() (interface{}, error) {
+		return g.getDomainNS(domain)
+	})
 	if err != nil {
 		return nil, err
 	}
-	return models.ToNameservers(nameservers)
+	return models.ToNameservers(nameservers.([]string))
 }
 
 // GetRegistrarCorrections returns a list of corrections for this registrar.
 func (client *gandiv5Provider) GetRegistrarCorrections(dc *models.DomainConfig) ([]*models.Correction, error) {
 	gd := gandi.NewDomainClient(client.apikey, gandi.Config{SharingID: client.sharingid, Debug: client.debug})
 
-	existingNs, err := gd.GetNameServers(dc.Name)
+	v, err := client.read(dc.Name, func() (interface{}, error) {
+		return gd.GetNameServers(dc.Name)
+	})
 	if err != nil {
 		return nil, err
 	}
+	existingNs := v.([]string)
 	sort.Strings(existingNs)
 	existing := strings.Join(existingNs, ",")
 
@@ -349,9 +483,10 @@ func (client *gandiv5Provider) GetRegistrarCorrections(dc *models.DomainConfig)
 		return []*models.Correction{
 			{
 				Msg: fmt.Sprintf("Change Nameservers from '%s' to '%s'", existing, desired),
-				F: func() (err error) {
-					err = gd.UpdateNameServers(dc.Name, desiredNs)
-					return
+				F: func() error {
+					return client.write(dc.Name, func() error {
+						return gd.UpdateNameServers(dc.Name, desiredNs)
+					})
 				}},
 		}, nil
 	}
@@ -0,0 +1,110 @@
+package gandi5
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/v3/models"
+	"github.com/go-gandi/go-gandi/livedns"
+)
+
+func TestCheckDomainNotFrozen(t *testing.T) {
+	if err := checkDomainNotFrozen("example.com", []string{"clientTransferProhibited"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := checkDomainNotFrozen("example.com", nil); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	err := checkDomainNotFrozen("example.com", []string{"clientTransferProhibited", "serverHold"})
+	if err == nil {
+		t.Fatal("expected an error for a frozen domain")
+	}
+	if !strings.Contains(err.Error(), "example.com is frozen") || !strings.Contains(err.Error(), "serverHold") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestRedact(t *testing.T) {
+	client := &gandiv5Provider{apikey: "s3cr3t", sharingid: "org-1234"}
+	err := client.redact(fmt.Errorf("GET domains/example.com?sharing_id=org-1234 with key s3cr3t: 403"))
+	if strings.Contains(err.Error(), "s3cr3t") || strings.Contains(err.Error(), "org-1234") {
+		t.Errorf("credentials were not redacted: %q", err)
+	}
+}
+
+func TestGenerateDomainCorrections_alias(t *testing.T) {
+	client := &gandiv5Provider{apikey: "test"}
+
+	dc := &models.DomainConfig{
+		Name:    "example.com",
+		Records: models.Records{makeRC("@", "ALIAS", "lb.example.net.")},
+	}
+	corrections, err := client.GenerateDomainCorrections(dc, models.Records{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(corrections) != 1 || !strings.Contains(corrections[0].Msg, "ALIAS example.com") {
+		t.Errorf("expected the apex ALIAS to be created; got=%v", corrections)
+	}
+
+	dc = &models.DomainConfig{
+		Name:    "example.com",
+		Records: models.Records{makeRC("www", "ALIAS", "lb.example.net.")},
+	}
+	_, err = client.GenerateDomainCorrections(dc, models.Records{})
+	if err == nil || !strings.Contains(err.Error(), "www.example.com") || !strings.Contains(err.Error(), "bare domain") {
+		t.Errorf("expected an error for an ALIAS below the bare domain; got=%v", err)
+	}
+}
+
+func TestGetDomainCorrections_alias(t *testing.T) {
+	fake := &fakeLiveDNS{}
+	client := &gandiv5Provider{apikey: "test", fakeLiveDNS: fake}
+	dc := &models.DomainConfig{
+		Name:    "example.com",
+		Records: models.Records{makeRC("www", "ALIAS", "lb.example.net.")},
+	}
+
+	// An ALIAS below the bare domain is still created as a CNAME.
+	corrections, err := client.GetDomainCorrections(dc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range corrections {
+		if err := c.F(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(fake.created) != 1 || fake.created[0] != "www CNAME" {
+		t.Errorf("expected the ALIAS to be created as a CNAME; got=%v", fake.created)
+	}
+}
+
+func TestGetZoneRecords_pages(t *testing.T) {
+	fake := &fakeLiveDNS{pageSize: 100}
+	for i := 0; i < 250; i++ {
+		fake.records = append(fake.records, livedns.DomainRecord{
+			RrsetName:   fmt.Sprintf("host%d", i),
+			RrsetType:   "A",
+			RrsetTTL:    300,
+			RrsetValues: []string{"10.0.0.1", "10.0.0.2"},
+		})
+	}
+	client := &gandiv5Provider{apikey: "test", fakeLiveDNS: fake}
+
+	records, err := client.GetZoneRecords("example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fake.calls != 3 {
+		t.Errorf("expected 3 pages to be read; got=%d", fake.calls)
+	}
+	if len(records) != 500 {
+		t.Fatalf("expected all 500 records; got=%d", len(records))
+	}
+	if last := records[len(records)-1]; last.GetLabel() != "host249" || last.GetTargetField() != "10.0.0.2" {
+		t.Errorf("expected the last record of the last page; got=%s %s", last.GetLabel(), last.GetTargetField())
+	}
+}
@@ -0,0 +1,228 @@
+package gandi5
+
+// API timeouts and retries, optionally overridden per domain.
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/StackExchange/dnscontrol/v3/pkg/printer"
+)
+
+// apiOptions controls how long a Gandi API call may take and how
+// often a failed call is retried.  A zero timeout means no timeout.
+// Calls that are rate limited are retried up to maxRetries times with
+// an increasing delay, independent of retries.
+type apiOptions struct {
+	timeout    time.Duration
+	retries    int
+	maxRetries int
+}
+
+// defaultMaxRetries is how often a rate limited call is retried unless
+// max_retries is set.
+const defaultMaxRetries = 3
+
+// rateLimitBackoff is the delay before the first retry of a rate
+// limited call.  It doubles with every further retry.
+var rateLimitBackoff = time.Second
+
+// domainOptions are the per-domain overrides found in the provider
+// metadata.  Unset fields keep the value from creds.json.
+type domainOptions struct {
+	Timeout string `json:"timeout"`
+	Retries *int   `json:"retries"`
+}
+
+// providerMetadata is the metadata passed to NewDnsProvider.
+type providerMetadata struct {
+	Domains map[string]domainOptions `json:"domains"`
+}
+
+// parseAPIOptions reads the base options from creds.json.
+func parseAPIOptions(m map[string]string) (apiOptions, error) {
+	opts := apiOptions{maxRetries: defaultMaxRetries}
+	if v := m["timeout"]; v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d < 0 {
+			return opts, fmt.Errorf("invalid Gandi timeout %q", v)
+		}
+		opts.timeout = d
+	}
+	if v := m["retries"]; v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return opts, fmt.Errorf("invalid Gandi retries %q", v)
+		}
+		opts.retries = n
+	}
+	if v := m["max_retries"]; v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return opts, fmt.Errorf("invalid Gandi max_retries %q", v)
+		}
+		opts.maxRetries = n
+	}
+	return opts, nil
+}
+
+// parseDomainOptions merges the per-domain overrides in metadata over base.
+func parseDomainOptions(metadata json.RawMessage, base apiOptions) (map[string]apiOptions, error) {
+	if len(metadata) == 0 {
+		return nil, nil
+	}
+	var meta providerMetadata
+	if err := json.Unmarshal(metadata, &meta); err != nil {
+		return nil, fmt.Errorf("invalid Gandi metadata: %w", err)
+	}
+
+	result := map[string]apiOptions{}
+	for domain, o := range meta.Domains {
+		opts := base
+		if o.Timeout != "" {
+			d, err := time.ParseDuration(o.Timeout)
+			if err != nil || d < 0 {
+				return nil, fmt.Errorf("invalid Gandi timeout %q for %s", o.Timeout, domain)
+			}
+			opts.timeout = d
+		}
+		if o.Retries != nil {
+			if *o.Retries < 0 {
+				return nil, fmt.Errorf("invalid Gandi retries %d for %s", *o.Retries, domain)
+			}
+			opts.retries = *o.Retries
+		}
+		result[domain] = opts
+	}
+	return result, nil
+}
+
+// optionsFor returns the API options that apply to domain.
+func (client *gandiv5Provider) optionsFor(domain string) apiOptions {
+	if opts, ok := client.domainOptions[domain]; ok {
+		return opts
+	}
+	return client.options
+}
+
+// read runs f, a read of domain, within that domain's timeout and
+// returns its result.  Reads are retried if they time out or fail with a
+// server error.  The credentials are redacted from the returned error.
+func (client *gandiv5Provider) read(domain string, f func() (interface{}, error)) (interface{}, error) {
+	opts := client.optionsFor(domain)
+	var v interface{}
+	var err error
+	for attempt := 0; attempt <= opts.retries; attempt++ {
+		if attempt > 0 {
+			printer.Warnf("Gandi: retrying %s (attempt %d of %d) after error: %v\n", domain, attempt+1, opts.retries+1, client.redact(err))
+		}
+		v, err = client.attempt(domain, opts, f)
+		if err == nil {
+			return v, nil
+		}
+		if !isRetryable(err) {
+			break
+		}
+	}
+	return nil, client.redact(err)
+}
+
+// write runs f, a change to domain, within that domain's timeout.  Writes
+// are only retried when they are rate limited, as Gandi did not process
+// them then.  A write that failed otherwise may still have been applied.
+// The credentials are redacted from the returned error.
+func (client *gandiv5Provider) write(domain string, f func() error) error {
+	_, err := client.attempt(domain, client.optionsFor(domain), func() (interface{}, error) {
+		return nil, f()
+	})
+	return client.redact(err)
+}
+
+// attempt runs f within opts.timeout, and again with an increasing delay
+// while it is rate limited, at most opts.maxRetries times.
+func (client *gandiv5Provider) attempt(domain string, opts apiOptions, f func() (interface{}, error)) (interface{}, error) {
+	v, err := callWithTimeout(opts.timeout, f)
+	backoff := rateLimitBackoff
+	for limited := 1; isRateLimited(err) && limited <= opts.maxRetries; limited++ {
+		printer.Warnf("Gandi: rate limited on %s, retrying in %s (%d of %d)\n", domain, backoff, limited, opts.maxRetries)
+		time.Sleep(backoff)
+		backoff *= 2
+		v, err = callWithTimeout(opts.timeout, f)
+	}
+	return v, err
+}
+
+// isRateLimited reports whether err is a "429 Too Many Requests" from
+// the Gandi API.
+func isRateLimited(err error) bool {
+	return statusCode(err) == http.StatusTooManyRequests
+}
+
+// isRetryable reports whether a read that failed with err is worth
+// retrying: it timed out or Gandi failed with a 5xx status.
+func isRetryable(err error) bool {
+	var timeout *timeoutError
+	if errors.As(err, &timeout) {
+		return true
+	}
+	return statusCode(err) >= 500
+}
+
+// statusCode returns the HTTP status of a Gandi API error, or 0 if there
+// is none.  The Gandi client only returns the status code as the start
+// of the error message, which may be wrapped since.
+func statusCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	for errors.Unwrap(err) != nil {
+		err = errors.Unwrap(err)
+	}
+	msg := err.Error()
+	if len(msg) < 3 || (len(msg) > 3 && msg[3] >= '0' && msg[3] <= '9') {
+		return 0
+	}
+	code, err := strconv.Atoi(msg[:3])
+	if err != nil {
+		return 0
+	}
+	return code
+}
+
+// timeoutError is returned when a call takes longer than its timeout.
+type timeoutError struct {
+	timeout time.Duration
+}
+
+func (e *timeoutError) Error() string {
+	return fmt.Sprintf("timed out after %s waiting for the Gandi API", e.timeout)
+}
+
+// callWithTimeout runs f and gives up waiting for it after timeout.
+// The Gandi client builds its own HTTP client and does not accept a
+// context, so a call that times out is abandoned rather than cancelled.
+// Its result is dropped, it is never seen by the caller.
+func callWithTimeout(timeout time.Duration, f func() (interface{}, error)) (interface{}, error) {
+	if timeout == 0 {
+		return f()
+	}
+	type result struct {
+		v   interface{}
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		v, err := f()
+		done <- result{v, err}
+	}()
+	select {
+	case r := <-done:
+		return r.v, r.err
+	case <-time.After(timeout):
+		return nil, &timeoutError{timeout}
+	}
+}
@@ -0,0 +1,265 @@
+package gandi5
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/StackExchange/dnscontrol/v3/models"
+	"github.com/go-gandi/go-gandi/livedns"
+)
+
+func TestDomainOptions(t *testing.T) {
+	metadata := json.RawMessage(`{"domains": {
+		"big.example": {"timeout": "2m", "retries": 5},
+		"slow.example": {"timeout": "90s"}
+	}}`)
+	client, err := newHelper(map[string]string{"apikey": "test", "timeout": "30s", "retries": "1"}, metadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = []struct {
+		domain  string
+		timeout time.Duration
+		retries int
+	}{
+		{"example.com", 30 * time.Second, 1},
+		{"big.example", 2 * time.Minute, 5},
+		{"slow.example", 90 * time.Second, 1},
+	}
+	for i, tst := range tests {
+		opts := client.optionsFor(tst.domain)
+		if opts.timeout != tst.timeout || opts.retries != tst.retries {
+			t.Errorf("%d: %s: Expected timeout=%s retries=%d, got timeout=%s retries=%d", i, tst.domain, tst.timeout, tst.retries, opts.timeout, opts.retries)
+		}
+	}
+}
+
+func TestDomainOptionsInvalid(t *testing.T) {
+	for i, tst := range []struct {
+		creds    map[string]string
+		metadata string
+	}{
+		{map[string]string{"apikey": "test", "timeout": "soon"}, ``},
+		{map[string]string{"apikey": "test", "retries": "-1"}, ``},
+		{map[string]string{"apikey": "test"}, `{"domains": {"example.com": {"timeout": "soon"}}}`},
+		{map[string]string{"apikey": "test"}, `{"domains": {"example.com": {"retries": -1}}}`},
+	} {
+		if _, err := newHelper(tst.creds, json.RawMessage(tst.metadata)); err == nil {
+			t.Errorf("%d: Expected an error", i)
+		}
+	}
+}
+
+func TestCallRetries(t *testing.T) {
+	client := &gandiv5Provider{
+		apikey:        "s3cr3t",
+		options:       apiOptions{retries: 0},
+		domainOptions: map[string]apiOptions{"big.example": {retries: 2}},
+	}
+
+	for i, tst := range []struct {
+		domain   string
+		attempts int
+	}{
+		{"example.com", 1},
+		{"big.example", 3},
+	} {
+		attempts := 0
+		_, err := client.read(tst.domain, func() (interface{}, error) {
+			attempts++
+			return nil, fmt.Errorf("500 for key s3cr3t")
+		})
+		if err == nil || strings.Contains(err.Error(), "s3cr3t") {
+			t.Errorf("%d: Expected a redacted error, got %v", i, err)
+		}
+		if attempts != tst.attempts {
+			t.Errorf("%d: Expected %d attempts, got %d", i, tst.attempts, attempts)
+		}
+	}
+
+	attempts := 0
+	v, err := client.read("big.example", func() (interface{}, error) {
+		attempts++
+		if attempts < 2 {
+			return nil, fmt.Errorf("502")
+		}
+		return "records", nil
+	})
+	if err != nil || attempts != 2 || v != "records" {
+		t.Errorf("Expected success on the second attempt, got v=%v err=%v attempts=%d", v, err, attempts)
+	}
+
+	// Client errors are not retried.
+	attempts = 0
+	_, err = client.read("big.example", func() (interface{}, error) {
+		attempts++
+		return nil, fmt.Errorf("404: not found")
+	})
+	if err == nil || attempts != 1 {
+		t.Errorf("Expected a single attempt, got err=%v attempts=%d", err, attempts)
+	}
+
+	// Writes may have been applied even if they failed.
+	attempts = 0
+	err = client.write("big.example", func() error {
+		attempts++
+		return fmt.Errorf("500")
+	})
+	if err == nil || attempts != 1 {
+		t.Errorf("Expected a write to be sent once, got err=%v attempts=%d", err, attempts)
+	}
+}
+
+func TestCallTimeout(t *testing.T) {
+	client := &gandiv5Provider{
+		domainOptions: map[string]apiOptions{"big.example": {timeout: 10 * time.Millisecond}},
+	}
+	release := make(chan struct{})
+	defer close(release)
+
+	_, err := client.read("big.example", func() (interface{}, error) {
+		<-release
+		return "late", nil
+	})
+	if err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Errorf("Expected a timeout, got %v", err)
+	}
+
+	// A write that timed out may still be applied, it is not sent again.
+	client.domainOptions["big.example"] = apiOptions{timeout: 10 * time.Millisecond, retries: 2}
+	var attempts int32
+	err = client.write("big.example", func() error {
+		atomic.AddInt32(&attempts, 1)
+		<-release
+		return nil
+	})
+	if n := atomic.LoadInt32(&attempts); err == nil || n != 1 {
+		t.Errorf("Expected a single timed out write, got err=%v attempts=%d", err, n)
+	}
+}
+
+// fakeLiveDNS is a LiveDNS API with the records of a single zone, in
+// pages of pageSize records if set.  Its calls fail with the errors in
+// errs first, one error per call.
+type fakeLiveDNS struct {
+	records  []livedns.DomainRecord
+	pageSize int
+	errs     []error
+	calls    int
+	created  []string
+}
+
+func (f *fakeLiveDNS) call() error {
+	f.calls++
+	if len(f.errs) == 0 {
+		return nil
+	}
+	err := f.errs[0]
+	f.errs = f.errs[1:]
+	return err
+}
+
+func (f *fakeLiveDNS) getDomainRecords(fqdn string, page int) ([]livedns.DomainRecord, bool, error) {
+	if err := f.call(); err != nil {
+		return nil, false, err
+	}
+	if f.pageSize == 0 {
+		return f.records, false, nil
+	}
+	start, end := (page-1)*f.pageSize, page*f.pageSize
+	if start > len(f.records) {
+		start = len(f.records)
+	}
+	if end > len(f.records) {
+		end = len(f.records)
+	}
+	return f.records[start:end], end < len(f.records), nil
+}
+
+func (f *fakeLiveDNS) getDomainNS(fqdn string) ([]string, error) {
+	return nil, f.call()
+}
+
+func (f *fakeLiveDNS) createDomainRecord(fqdn, name, rtype string, ttl int, values []string) error {
+	if err := f.call(); err != nil {
+		// Like liveDNSClient, which adds the response to the error.
+		return fmt.Errorf("%+v: %w", struct{ Message string }{"Too many requests"}, err)
+	}
+	f.created = append(f.created, name+" "+rtype)
+	return nil
+}
+
+func (f *fakeLiveDNS) updateDomainRecordsByName(fqdn, name string, records []livedns.DomainRecord) error {
+	return f.call()
+}
+
+func (f *fakeLiveDNS) deleteDomainRecordsByName(fqdn, name string) error {
+	return f.call()
+}
+
+func TestCallRateLimited(t *testing.T) {
+	oldBackoff := rateLimitBackoff
+	rateLimitBackoff = time.Millisecond
+	defer func() { rateLimitBackoff = oldBackoff }()
+
+	fake := &fakeLiveDNS{
+		records: []livedns.DomainRecord{{RrsetName: "www", RrsetType: "A", RrsetTTL: 300, RrsetValues: []string{"1.2.3.4"}}},
+		errs:    []error{errors.New("429: Too many requests")},
+	}
+	client, err := newHelper(map[string]string{"apikey": "test", "max_retries": "1"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.fakeLiveDNS = fake
+	records, err := client.GetZoneRecords("example.com")
+	if err != nil {
+		t.Fatalf("Expected success after the 429, got %v", err)
+	}
+	if fake.calls != 2 || len(records) != 1 {
+		t.Errorf("Expected 2 requests and 1 record, got %d requests and %d records", fake.calls, len(records))
+	}
+
+	// Without retries the 429 is returned.
+	fake.calls, fake.errs = 0, []error{errors.New("429: Too many requests")}
+	client.options.maxRetries = 0
+	if _, err := client.GetZoneRecords("example.com"); err == nil || !isRateLimited(err) {
+		t.Errorf("Expected a 429 error, got %v", err)
+	}
+}
+
+func TestCallRateLimitedWrite(t *testing.T) {
+	oldBackoff := rateLimitBackoff
+	rateLimitBackoff = time.Millisecond
+	defer func() { rateLimitBackoff = oldBackoff }()
+
+	fake := &fakeLiveDNS{errs: []error{errors.New("429: Too many requests")}}
+	client, err := newHelper(map[string]string{"apikey": "test", "max_retries": "1"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.fakeLiveDNS = fake
+	dc := &models.DomainConfig{
+		Name:    "example.com",
+		Records: models.Records{makeRC("www", "A", "1.2.3.4")},
+	}
+
+	corrections, err := client.GenerateDomainCorrections(dc, models.Records{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(corrections) != 1 {
+		t.Fatalf("Expected the creation of www, got %v", corrections)
+	}
+	if err := corrections[0].F(); err != nil {
+		t.Fatalf("Expected success after the 429, got %v", err)
+	}
+	if fake.calls != 2 || len(fake.created) != 1 {
+		t.Errorf("Expected 2 requests and 1 created record, got %d requests and %v", fake.calls, fake.created)
+	}
+}
@@ -2,35 +2,222 @@ package hetzner
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"net"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
+
+	"github.com/StackExchange/dnscontrol/v3/models"
+	"github.com/StackExchange/dnscontrol/v3/pkg/printer"
+	"github.com/StackExchange/dnscontrol/v3/pkg/redact"
 )
 
 const (
-	baseURL = "https://dns.hetzner.com/api/v1"
+	defaultBaseURL = "https://dns.hetzner.com/api/v1"
+	// maxResponseSize caps how much of a response body is read, a
+	// misbehaving proxy must not be able to exhaust the memory.
+	maxResponseSize = 1 << 20
+	// defaultPageSize is the number of records or zones requested at once.
+	defaultPageSize = 100
+	// defaultMaxPages caps how many pages are fetched when listing records
+	// or zones, in case the API keeps reporting a next page.
+	defaultMaxPages = 1000
+	// bulkDeleteThreshold is the number of deletions from which records
+	// are deleted in parallel even if delete_concurrency is not set,
+	// e.g. when tearing down a zone.
+	bulkDeleteThreshold = 10
+	// bulkDeleteConcurrency is the number of parallel deletions then.
+	bulkDeleteConcurrency = 4
 )
 
 type hetznerProvider struct {
 	apiKey             string
-	zones              map[string]zone
+	baseURL            string
+	defaultTTL         uint32
+	ttlTolerance       uint32
+	maxChanges         int
+	maxChangesOverride bool
+	nameservers        []string
+	readOnly           bool
+	noCreateZones      bool
+	pruneOnly          bool
+	showPayloads       bool
+	onConflict         string // "", "skip" or "update".
+	secondaryZones     bool
+	primaryServers     []PrimaryServer // Of the secondary zones created.
+	zonesConcurrency   int
+	concurrency        int
+	deleteConcurrency  int             // 0 means parallelDeletes decides.
+	pageSize           int             // 0 means defaultPageSize.
+	maxPages           int             // 0 means defaultMaxPages.
+	zones              map[string]zone // Never modified, replaced instead.
+	zonesMu            sync.Mutex      // Guards zones and listing them.
 	requestRateLimiter requestRateLimiter
+	// lookupIP resolves the targets of ALIAS records. It defaults to
+	// net.LookupIP.
+	lookupIP func(host string) ([]net.IP, error)
+	// transport sends the requests to HETZNER. nil means
+	// http.DefaultTransport.
+	transport http.RoundTripper
+	// logRequest, if set, is called after each request sent to HETZNER.
+	logRequest func(requestLog)
+	// fakeClient replaces the API calls made when computing and applying
+	// corrections, for testing.
+	fakeClient hetznerClient
+}
+
+// requestLog describes a request sent to HETZNER.
+type requestLog struct {
+	Method     string
+	Path       string
+	StatusCode int // 0 if no response was received.
+	Duration   time.Duration
+}
+
+// printRequestLog prints entry as key=value pairs.
+func printRequestLog(entry requestLog) {
+	printer.Printf("HETZNER: method=%s path=%q status=%d duration=%s\n", entry.Method, entry.Path, entry.StatusCode, entry.Duration)
+}
+
+// hetznerClient is the part of the API used to compute and apply
+// corrections. hetznerProvider implements it by sending requests.
+type hetznerClient interface {
+	bulkCreateRecords(records []record) error
+	bulkUpdateRecords(records []record) error
+	deleteRecord(record record) error
+	deleteRecords(records []record) error
+	exportZoneFile(zoneID string) (string, error)
+	getAllRecordsInZone(zone *zone) ([]record, error)
+	getZone(name string) (*zone, error)
+	importZoneFile(zoneID string, zoneText string) error
+	updateZone(zone *zone, ttl int) error
+}
+
+func (api *hetznerProvider) client() hetznerClient {
+	if api.fakeClient != nil {
+		return api.fakeClient
+	}
+	return api
+}
+
+// errReadOnly is returned for any request that would change something
+// while read_only is set.
+var errReadOnly = errors.New("read-only mode")
+
+// apiError is returned for any response with an unexpected status code.
+type apiError struct {
+	StatusCode int
+	Message    string
+}
+
+func newAPIError(statusCode int, body []byte) *apiError {
+	response := &errorResponse{}
+	message := strings.TrimSpace(string(body))
+	if json.Unmarshal(body, response) == nil {
+		if response.Error.Message != "" {
+			message = response.Error.Message
+		} else if response.Message != "" {
+			message = response.Message
+		}
+	}
+	return &apiError{StatusCode: statusCode, Message: message}
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("%d: %s", e.StatusCode, e.Message)
+}
+
+// isConflict reports whether err is HETZNER refusing to create a record
+// because it already exists.
+func isConflict(err error) bool {
+	var apiErr *apiError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	return apiErr.StatusCode == http.StatusConflict ||
+		(apiErr.StatusCode == http.StatusUnprocessableEntity && strings.Contains(strings.ToLower(apiErr.Message), "already exists"))
 }
 
 func checkIsLockedSystemRecord(record record) error {
 	if record.Type == "SOA" {
-		// The upload of a BIND zone file can change the SOA record.
-		// Implementing this edge case this is too complex for now.
+		// Only the upload of a BIND zone file can change the SOA record,
+		// see soaCorrection.
 		return fmt.Errorf("SOA records are locked in HETZNER zones. They are hence not available for updating")
 	}
 	return nil
 }
 
+func checkCAA(rc *models.RecordConfig) error {
+	if rc.Type != "CAA" {
+		return nil
+	}
+	switch rc.CaaTag {
+	case "issue", "issuewild", "iodef":
+	default:
+		return fmt.Errorf("CAA record %s has an invalid tag %q, expected issue, issuewild or iodef", rc.GetLabelFQDN(), rc.CaaTag)
+	}
+	return nil
+}
+
+func checkTLSA(rc *models.RecordConfig) error {
+	if rc.Type != "TLSA" {
+		return nil
+	}
+	if rc.TlsaUsage > 3 {
+		return fmt.Errorf("TLSA record %s has an invalid usage %d, expected 0 to 3", rc.GetLabelFQDN(), rc.TlsaUsage)
+	}
+	if rc.TlsaSelector > 1 {
+		return fmt.Errorf("TLSA record %s has an invalid selector %d, expected 0 or 1", rc.GetLabelFQDN(), rc.TlsaSelector)
+	}
+	if rc.TlsaMatchingType > 2 {
+		return fmt.Errorf("TLSA record %s has an invalid matching type %d, expected 0 to 2", rc.GetLabelFQDN(), rc.TlsaMatchingType)
+	}
+	association := rc.GetTargetField()
+	data, err := hex.DecodeString(association)
+	if err != nil || len(data) == 0 {
+		return fmt.Errorf("TLSA record %s has an invalid certificate association %q, expected hex digits", rc.GetLabelFQDN(), association)
+	}
+	// SHA-256 and SHA-512 digests have a fixed length.
+	if size := map[uint8]int{1: 32, 2: 64}[rc.TlsaMatchingType]; size != 0 && len(data) != size {
+		return fmt.Errorf("TLSA record %s has a certificate association of %d bytes, expected %d for matching type %d", rc.GetLabelFQDN(), len(data), size, rc.TlsaMatchingType)
+	}
+	return nil
+}
+
+func checkIsZoneReady(zone *zone) error {
+	switch zone.Status {
+	case "", "verified":
+		// Older responses may lack the status. Assume the zone is usable.
+		return nil
+	case "pending":
+		// HETZNER rejects every record change until the nameservers of the
+		// domain point to HETZNER.
+		return fmt.Errorf("HETZNER zone %q is not ready for changes, it is not verified yet (status %q): delegate the domain to the HETZNER nameservers first", zone.Name, zone.Status)
+	default:
+		return fmt.Errorf("HETZNER zone %q is not ready for changes (status %q), try again once it is verified", zone.Name, zone.Status)
+	}
+}
+
+// idempotencyKey returns the key for a write, a hash of the request. A
+// write that is sent again, e.g. after its response was lost, has the same
+// key, so that it can be recognized as a duplicate.
+func idempotencyKey(method, endpoint string, body []byte) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s %s\n", method, endpoint)
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
 func getHomogenousDelay(headers http.Header, quotaName string) (time.Duration, error) {
 	quota, err := parseHeaderAsInt(headers, "X-Ratelimit-Limit-"+strings.Title(quotaName))
 	if err != nil {
@@ -101,7 +288,7 @@ func (api *hetznerProvider) createRecord(record record) error {
 
 	request := createRecordRequest{
 		Name:   record.Name,
-		TTL:    *record.TTL,
+		TTL:    record.TTL,
 		Type:   record.Type,
 		Value:  record.Value,
 		ZoneID: record.ZoneID,
@@ -111,9 +298,17 @@ func (api *hetznerProvider) createRecord(record record) error {
 
 func (api *hetznerProvider) createZone(name string) error {
 	request := createZoneRequest{
-		Name: name,
+		Name:           name,
+		IsSecondaryDNS: api.secondaryZones,
 	}
-	return api.request("/zones", "POST", request, nil)
+	response := &getZoneResponse{}
+	if err := api.request("/zones", "POST", request, response); err != nil {
+		return err
+	}
+	if !api.secondaryZones || len(api.primaryServers) == 0 {
+		return nil
+	}
+	return api.updatePrimaryServers(&response.Zone, api.primaryServers)
 }
 
 func (api *hetznerProvider) deleteRecord(record record) error {
@@ -125,21 +320,77 @@ func (api *hetznerProvider) deleteRecord(record record) error {
 	return api.request(url, "DELETE", nil, nil)
 }
 
-func (api *hetznerProvider) getAllRecords(domain string) ([]record, error) {
-	zone, err := api.getZone(domain)
-	if err != nil {
-		return nil, err
+// parallelDeletes returns how many of n records are deleted in
+// parallel: delete_concurrency if set, otherwise
+// bulkDeleteConcurrency for at least bulkDeleteThreshold records.
+func (api *hetznerProvider) parallelDeletes(n int) int {
+	if n < 2 {
+		return 1
+	}
+	if api.deleteConcurrency > 0 {
+		return api.deleteConcurrency
+	}
+	if n >= bulkDeleteThreshold {
+		return bulkDeleteConcurrency
+	}
+	return 1
+}
+
+// deleteRecords deletes records using up to parallelDeletes parallel
+// requests, fewer while HETZNER rate-limits the requests.
+func (api *hetznerProvider) deleteRecords(records []record) error {
+	pool := newAdaptivePool(api.parallelDeletes(len(records)))
+	errs := pool.run(len(records), api.requestRateLimiter.rateLimitedCount, func(i int) error {
+		return api.deleteRecord(records[i])
+	})
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (api *hetznerProvider) exportZoneFile(zoneID string) (string, error) {
+	var zoneText string
+	url := fmt.Sprintf("/zones/%s/export", zoneID)
+	if err := api.request(url, "GET", nil, &zoneText); err != nil {
+		return "", err
 	}
+	return zoneText, nil
+}
+
+// perPage returns the number of records or zones to request at once.
+func (api *hetznerProvider) perPage() int {
+	if api.pageSize > 0 {
+		return api.pageSize
+	}
+	return defaultPageSize
+}
+
+// pageLimit returns the number of pages to fetch at most.
+func (api *hetznerProvider) pageLimit() int {
+	if api.maxPages > 0 {
+		return api.maxPages
+	}
+	return defaultMaxPages
+}
+
+func (api *hetznerProvider) getAllRecordsInZone(zone *zone) ([]record, error) {
 	page := 1
 	records := make([]record, 0)
 	for {
 		response := &getAllRecordsResponse{}
-		url := fmt.Sprintf("/records?zone_id=%s&per_page=100&page=%d", zone.ID, page)
+		url := fmt.Sprintf("/records?zone_id=%s&per_page=%d&page=%d", zone.ID, api.perPage(), page)
 		if err := api.request(url, "GET", nil, response); err != nil {
-			return nil, fmt.Errorf("failed fetching zone records for %q: %w", domain, err)
+			return nil, fmt.Errorf("failed fetching zone records for %q: %w", zone.Name, err)
+		}
+		if p := response.Meta.Pagination; page == 1 && p.LastPage > 1 {
+			printer.Printf("HETZNER: fetching %d records of %q in %d pages\n", p.TotalEntries, zone.Name, p.LastPage)
 		}
 		for _, record := range response.Records {
-			if record.TTL == nil {
+			if record.TTL == nil || *record.TTL == 0 {
+				// The record uses the zone's default TTL.
 				record.TTL = &zone.TTL
 			}
 
@@ -154,73 +405,209 @@ func (api *hetznerProvider) getAllRecords(domain string) ([]record, error) {
 		if page >= response.Meta.Pagination.LastPage {
 			break
 		}
+		if page >= api.pageLimit() {
+			return nil, fmt.Errorf("failed fetching zone records for %q: more than %d pages, set max_pages to fetch more", zone.Name, api.pageLimit())
+		}
 		page++
 	}
 	return records, nil
 }
 
+func (api *hetznerProvider) getAllPrimaryServers(zone *zone) ([]primaryServer, error) {
+	response := &getAllPrimaryServersResponse{}
+	url := fmt.Sprintf("/primary_servers?zone_id=%s", zone.ID)
+	if err := api.request(url, "GET", nil, response); err != nil {
+		return nil, fmt.Errorf("failed fetching primary servers for %q: %w", zone.Name, err)
+	}
+	return response.PrimaryServers, nil
+}
+
+// getAllZones lists the zones of the account once and caches them.
+// Concurrent callers wait for and share a single listing.
 func (api *hetznerProvider) getAllZones() error {
+	_, err := api.cachedZones()
+	return err
+}
+
+// cachedZones returns the cached zones, listing them on first use.
+func (api *hetznerProvider) cachedZones() (map[string]zone, error) {
+	api.zonesMu.Lock()
+	defer api.zonesMu.Unlock()
 	if api.zones != nil {
-		return nil
+		return api.zones, nil
 	}
+	zones, err := api.listAllZones()
+	if err != nil {
+		return nil, err
+	}
+	api.zones = zones
+	return zones, nil
+}
+
+// resetZones drops the cached zones, they are listed again on next use.
+func (api *hetznerProvider) resetZones() {
+	api.zonesMu.Lock()
+	defer api.zonesMu.Unlock()
+	api.zones = nil
+}
+
+// cacheZone adds z to the cached zones, if they have been listed.
+func (api *hetznerProvider) cacheZone(z zone) {
+	api.zonesMu.Lock()
+	defer api.zonesMu.Unlock()
+	if api.zones == nil {
+		return
+	}
+	zones := make(map[string]zone, len(api.zones)+1)
+	for name, cached := range api.zones {
+		zones[name] = cached
+	}
+	zones[z.Name] = z
+	api.zones = zones
+}
+
+func (api *hetznerProvider) listAllZones() (map[string]zone, error) {
 	zones := map[string]zone{}
+	seen := map[string]bool{}
 	page := 1
 	for {
 		response := &getAllZonesResponse{}
-		url := fmt.Sprintf("/zones?per_page=100&page=%d", page)
+		url := fmt.Sprintf("/zones?per_page=%d&page=%d", api.perPage(), page)
 		if err := api.request(url, "GET", nil, response); err != nil {
-			return fmt.Errorf("failed fetching zones: %w", err)
+			return nil, fmt.Errorf("failed fetching zones: %w", err)
 		}
 		for _, zone := range response.Zones {
+			// Pages may overlap when zones are created or deleted meanwhile.
+			if seen[zone.ID] {
+				continue
+			}
+			seen[zone.ID] = true
 			zones[zone.Name] = zone
 		}
 		// meta.pagination may not be present. In that case LastPage is 0 and below the current page number.
 		if page >= response.Meta.Pagination.LastPage {
 			break
 		}
+		if page >= api.pageLimit() {
+			return nil, fmt.Errorf("failed fetching zones: more than %d pages, set max_pages to fetch more", api.pageLimit())
+		}
 		page++
 	}
-	api.zones = zones
-	return nil
+	return zones, nil
 }
 
+// getZone returns the zone with the given name. The zones are listed
+// once and shared by all domains.
 func (api *hetznerProvider) getZone(name string) (*zone, error) {
-	if err := api.getAllZones(); err != nil {
+	zones, err := api.cachedZones()
+	if err != nil {
 		return nil, err
 	}
-	zone, ok := api.zones[name]
-	if !ok {
+	if z, ok := zones[name]; ok {
+		return &z, nil
+	}
+	// The zone may have been created since the zones were listed.
+	z, err := api.getZoneByName(name)
+	if err != nil {
+		return nil, err
+	}
+	api.cacheZone(*z)
+	return z, nil
+}
+
+// getZoneByName asks HETZNER for the zone with the given name only.
+func (api *hetznerProvider) getZoneByName(name string) (*zone, error) {
+	response := &getAllZonesResponse{}
+	endpoint := fmt.Sprintf("/zones?name=%s", url.QueryEscape(name))
+	if err := api.request(endpoint, "GET", nil, response); err != nil {
+		return nil, fmt.Errorf("failed fetching zone %q: %w", name, err)
+	}
+	var matches []zone
+	for _, z := range response.Zones {
+		// Double-check, the filter may not be exact.
+		if z.Name == name {
+			matches = append(matches, z)
+		}
+	}
+	switch len(matches) {
+	case 0:
 		return nil, fmt.Errorf("%q is not a zone in this HETZNER account", name)
+	case 1:
+		return &matches[0], nil
 	}
-	return &zone, nil
+	return nil, fmt.Errorf("%q is ambiguous, HETZNER reports %d zones of that name", name, len(matches))
+}
+
+func (api *hetznerProvider) importZoneFile(zoneID string, zoneText string) error {
+	url := fmt.Sprintf("/zones/%s/import", zoneID)
+	return api.requestRaw(url, "POST", "text/plain", []byte(zoneText), nil)
 }
 
 func (api *hetznerProvider) request(endpoint string, method string, request interface{}, target interface{}) error {
+	var body []byte
+	if request != nil {
+		var err error
+		body, err = json.Marshal(request)
+		if err != nil {
+			return err
+		}
+	}
+	return api.requestRaw(endpoint, method, "application/json", body, target)
+}
+
+func (api *hetznerProvider) requestRaw(endpoint string, method string, contentType string, body []byte, target interface{}) error {
+	var err error
+	if api.readOnly && method != "GET" {
+		err = errReadOnly
+	} else {
+		err = api.doRequest(endpoint, method, contentType, body, target)
+	}
+	if err != nil {
+		err = fmt.Errorf("HETZNER %s %s: %w", method, endpoint, err)
+	}
+	return redact.Error(err, api.apiKey)
+}
+
+func (api *hetznerProvider) doRequest(endpoint string, method string, contentType string, body []byte, target interface{}) error {
+	var key string
+	if method == "POST" || method == "PUT" {
+		key = idempotencyKey(method, endpoint, body)
+	}
 	for {
 		var requestBody io.Reader
-		if request != nil {
-			requestBodySerialised, err := json.Marshal(request)
-			if err != nil {
-				return err
-			}
-			requestBody = bytes.NewBuffer(requestBodySerialised)
+		if body != nil {
+			requestBody = bytes.NewReader(body)
 		}
-		req, err := http.NewRequest(method, baseURL+endpoint, requestBody)
+		req, err := http.NewRequest(method, api.baseURL+endpoint, requestBody)
 		if err != nil {
 			return err
 		}
+		if body != nil {
+			req.Header.Add("Content-Type", contentType)
+		}
 		req.Header.Add("Auth-API-Token", api.apiKey)
+		if key != "" {
+			req.Header.Add("Idempotency-Key", key)
+		}
 
 		api.requestRateLimiter.beforeRequest()
-		resp, err := http.DefaultClient.Do(req)
+		start := time.Now()
+		resp, err := (&http.Client{Transport: api.transport}).Do(req)
 		api.requestRateLimiter.afterRequest()
+		if api.logRequest != nil {
+			entry := requestLog{Method: method, Path: endpoint, Duration: time.Since(start)}
+			if resp != nil {
+				entry.StatusCode = resp.StatusCode
+			}
+			api.logRequest(entry)
+		}
 		if err != nil {
 			return err
 		}
 		cleanupResponseBody := func() {
 			err := resp.Body.Close()
 			if err != nil {
-				fmt.Println(fmt.Sprintf("failed closing response body: %q", err))
+				printer.Warnf("HETZNER: failed closing response body: %q\n", err)
 			}
 		}
 
@@ -234,15 +621,31 @@ func (api *hetznerProvider) request(endpoint string, method string, request inte
 
 		defer cleanupResponseBody()
 		if resp.StatusCode != 200 {
-			data, _ := ioutil.ReadAll(resp.Body)
-			fmt.Println(string(data))
-			return fmt.Errorf("bad status code from HETZNER: %d not 200", resp.StatusCode)
+			data, _ := ioutil.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+			return newAPIError(resp.StatusCode, data)
 		}
 		if target == nil {
 			return nil
 		}
-		decoder := json.NewDecoder(resp.Body)
-		return decoder.Decode(target)
+		// Read one byte more than allowed, a truncated body must not be
+		// mistaken for a complete one, e.g. a zone file to import again.
+		data, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxResponseSize+1))
+		if err != nil {
+			return err
+		}
+		if len(data) > maxResponseSize {
+			return fmt.Errorf("response exceeds %d bytes", maxResponseSize)
+		}
+		if text, ok := target.(*string); ok {
+			// Not every endpoint responds with JSON.
+			*text = string(data)
+			return nil
+		}
+		if len(bytes.TrimSpace(data)) == 0 {
+			// Some endpoints, e.g. DELETE, respond without a body.
+			return nil
+		}
+		return json.Unmarshal(data, target)
 	}
 }
 
@@ -255,6 +658,56 @@ func (api *hetznerProvider) startRateLimited() {
 	api.requestRateLimiter.setDefaultDelay()
 }
 
+func (api *hetznerProvider) updateZone(zone *zone, ttl int) error {
+	if zone.ID == "" {
+		// PUT /zones/ would not tell HETZNER which zone to update.
+		return fmt.Errorf("cannot update zone %q without its ID", zone.Name)
+	}
+	request := updateZoneRequest{
+		Name: zone.Name,
+		TTL:  ttl,
+	}
+	url := fmt.Sprintf("/zones/%s", zone.ID)
+	return api.request(url, "PUT", request, nil)
+}
+
+// updatePrimaryServers makes servers the primary servers of the secondary
+// zone, deleting all others.
+func (api *hetznerProvider) updatePrimaryServers(zone *zone, servers []PrimaryServer) error {
+	if zone.ID == "" {
+		return fmt.Errorf("cannot update the primary servers of zone %q without its ID", zone.Name)
+	}
+	existing, err := api.getAllPrimaryServers(zone)
+	if err != nil {
+		return err
+	}
+	wanted := map[PrimaryServer]bool{}
+	for _, s := range servers {
+		wanted[s] = true
+	}
+	for _, s := range existing {
+		key := PrimaryServer{Address: s.Address, Port: s.Port}
+		if wanted[key] {
+			delete(wanted, key)
+			continue
+		}
+		if err := api.request(fmt.Sprintf("/primary_servers/%s", s.ID), "DELETE", nil, nil); err != nil {
+			return err
+		}
+	}
+	for _, s := range servers {
+		if !wanted[s] {
+			continue
+		}
+		delete(wanted, s)
+		request := primaryServer{Address: s.Address, Port: s.Port, ZoneID: zone.ID}
+		if err := api.request("/primary_servers", "POST", request, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (api *hetznerProvider) updateRecord(record record) error {
 	if err := checkIsLockedSystemRecord(record); err != nil {
 		return err
@@ -265,25 +718,47 @@ func (api *hetznerProvider) updateRecord(record record) error {
 }
 
 type requestRateLimiter struct {
+	// mu guards delay, lastRequest and rateLimited, requests may be sent concurrently.
+	mu                        sync.Mutex
 	delay                     time.Duration
 	lastRequest               time.Time
+	rateLimited               uint64
 	optimizeForRateLimitQuota string
 }
 
 func (requestRateLimiter *requestRateLimiter) afterRequest() {
-	requestRateLimiter.lastRequest = time.Now()
+	requestRateLimiter.mu.Lock()
+	defer requestRateLimiter.mu.Unlock()
+	if now := time.Now(); now.After(requestRateLimiter.lastRequest) {
+		requestRateLimiter.lastRequest = now
+	}
 }
 
 func (requestRateLimiter *requestRateLimiter) beforeRequest() {
+	requestRateLimiter.mu.Lock()
 	if requestRateLimiter.delay == 0 {
+		requestRateLimiter.mu.Unlock()
 		return
 	}
-	time.Sleep(time.Until(requestRateLimiter.lastRequest.Add(requestRateLimiter.delay)))
+	// Reserve the next slot, so concurrent requests are spaced out as well.
+	next := requestRateLimiter.lastRequest.Add(requestRateLimiter.delay)
+	if now := time.Now(); now.After(next) {
+		next = now
+	}
+	requestRateLimiter.lastRequest = next
+	requestRateLimiter.mu.Unlock()
+	time.Sleep(time.Until(next))
 }
 
 func (requestRateLimiter *requestRateLimiter) setDefaultDelay() {
 	// default to a rate-limit of 1 req/s -- the next response should update it.
-	requestRateLimiter.delay = time.Second
+	requestRateLimiter.setDelay(time.Second)
+}
+
+func (requestRateLimiter *requestRateLimiter) setDelay(delay time.Duration) {
+	requestRateLimiter.mu.Lock()
+	defer requestRateLimiter.mu.Unlock()
+	requestRateLimiter.delay = delay
 }
 
 func (requestRateLimiter *requestRateLimiter) setOptimizeForRateLimitQuota(quota string) error {
@@ -300,6 +775,10 @@ func (requestRateLimiter *requestRateLimiter) setOptimizeForRateLimitQuota(quota
 }
 
 func (requestRateLimiter *requestRateLimiter) handleRateLimitedRequest() {
+	requestRateLimiter.mu.Lock()
+	requestRateLimiter.rateLimited++
+	requestRateLimiter.mu.Unlock()
+
 	message := "Rate-Limited, consider bumping the setting 'optimize_for_rate_limit_quota': %q -> %q"
 	switch requestRateLimiter.optimizeForRateLimitQuota {
 	case "hour":
@@ -309,7 +788,14 @@ func (requestRateLimiter *requestRateLimiter) handleRateLimitedRequest() {
 	case "second":
 		message = fmt.Sprintf(message, "Second", "Minute")
 	}
-	fmt.Println(message)
+	printer.Warnf("HETZNER: %s\n", message)
+}
+
+// rateLimitedCount returns the number of requests that were rate-limited.
+func (requestRateLimiter *requestRateLimiter) rateLimitedCount() uint64 {
+	requestRateLimiter.mu.Lock()
+	defer requestRateLimiter.mu.Unlock()
+	return requestRateLimiter.rateLimited
 }
 
 func (requestRateLimiter *requestRateLimiter) handleResponse(resp http.Response) {
@@ -326,5 +812,5 @@ func (requestRateLimiter *requestRateLimiter) handleResponse(resp http.Response)
 			delay = retryAfterDelay
 		}
 	}
-	requestRateLimiter.delay = delay
+	requestRateLimiter.setDelay(delay)
 }
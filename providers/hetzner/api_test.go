@@ -0,0 +1,480 @@
+package hetzner
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/v3/models"
+	"github.com/StackExchange/dnscontrol/v3/pkg/printer"
+)
+
+func TestCreateZone_secondary(t *testing.T) {
+	var body string
+	api := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/zones" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+		}
+		data, _ := ioutil.ReadAll(r.Body)
+		body = string(data)
+	})
+
+	if err := api.createZone("example.com"); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(body, "is_secondary_dns") {
+		t.Errorf("primary zones should not send is_secondary_dns; got=%s", body)
+	}
+
+	api.secondaryZones = true
+	if err := api.createZone("example.com"); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(body, `"is_secondary_dns":true`) {
+		t.Errorf("expected is_secondary_dns in the payload; got=%s", body)
+	}
+}
+
+func TestCreateZone_primaryServers(t *testing.T) {
+	var servers []primaryServer
+	api := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/zones":
+			fmt.Fprint(w, `{"zone":{"id":"zone1","name":"example.com","is_secondary_dns":true}}`)
+		case r.Method == "GET" && r.URL.Path == "/zones":
+			fmt.Fprint(w, `{"zones":[{"id":"zone1","name":"example.com","is_secondary_dns":true}]}`)
+		case r.Method == "POST" && r.URL.Path == "/primary_servers":
+			var s primaryServer
+			if err := json.NewDecoder(r.Body).Decode(&s); err != nil {
+				t.Fatal(err)
+			}
+			s.ID = fmt.Sprintf("ps%d", len(servers))
+			servers = append(servers, s)
+		case r.Method == "GET" && r.URL.Path == "/primary_servers":
+			if got := r.URL.Query().Get("zone_id"); got != "zone1" {
+				t.Errorf("expected the primary servers of zone1; got=%q", got)
+			}
+			json.NewEncoder(w).Encode(getAllPrimaryServersResponse{PrimaryServers: servers})
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+		}
+	})
+	api.secondaryZones = true
+	for _, s := range []string{"192.0.2.1", "[2001:db8::1]:5353"} {
+		ps, err := parsePrimaryServer(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		api.primaryServers = append(api.primaryServers, ps)
+	}
+
+	if err := api.createZone("example.com"); err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range servers {
+		if s.ZoneID != "zone1" {
+			t.Errorf("expected the primary server to belong to zone1; got=%+v", s)
+		}
+	}
+
+	got, err := api.getAllPrimaryServers(&zone{ID: "zone1", Name: "example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []PrimaryServer{{Address: "192.0.2.1", Port: 53}, {Address: "2001:db8::1", Port: 5353}}
+	if len(got) != len(want) {
+		t.Fatalf("expected the primary servers %v; got=%+v", want, got)
+	}
+	for i, s := range got {
+		if s.Address != want[i].Address || s.Port != want[i].Port {
+			t.Errorf("expected the primary server %v; got=%+v", want[i], s)
+		}
+	}
+}
+
+func TestGetZoneByName(t *testing.T) {
+	api := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/zones" || r.URL.Query().Get("page") != "" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+		}
+		switch r.URL.Query().Get("name") {
+		case "a.com":
+			// The filter may match more than the exact name.
+			fmt.Fprint(w, `{"zones":[{"id":"z1","name":"a.com"},{"id":"z3","name":"sub.a.com"}]}`)
+		case "b.com":
+			fmt.Fprint(w, `{"zones":[{"id":"z2","name":"b.com"},{"id":"z4","name":"b.com"}]}`)
+		default:
+			fmt.Fprint(w, `{"zones":[]}`)
+		}
+	})
+
+	z, err := api.getZoneByName("a.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if z.ID != "z1" {
+		t.Errorf("expected zone z1; got=%+v", z)
+	}
+	if _, err := api.getZoneByName("c.com"); err == nil || !strings.Contains(err.Error(), "is not a zone") {
+		t.Errorf("expected a not-found error; got=%v", err)
+	}
+	if _, err := api.getZoneByName("b.com"); err == nil || !strings.Contains(err.Error(), "ambiguous") {
+		t.Errorf("expected an error for an ambiguous name; got=%v", err)
+	}
+}
+
+// recordingTransport records the requests it forwards to next.
+type recordingTransport struct {
+	next     http.RoundTripper
+	requests []string
+}
+
+func (rt *recordingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	rt.requests = append(rt.requests, r.Method+" "+r.URL.Path)
+	return rt.next.RoundTrip(r)
+}
+
+func TestWithTransport(t *testing.T) {
+	server := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"zones":[{"id":"zone1","name":"example.com"}]}`)
+	})
+	rt := &recordingTransport{next: http.DefaultTransport}
+	provider, err := NewWithOptions(map[string]string{"api_key": "test"}, nil, WithTransport(rt))
+	if err != nil {
+		t.Fatal(err)
+	}
+	api := provider.(*hetznerProvider)
+	api.baseURL = server.baseURL
+
+	if _, err := api.ListZones(); err != nil {
+		t.Fatal(err)
+	}
+	if len(rt.requests) != 1 || rt.requests[0] != "GET /zones" {
+		t.Errorf("expected the transport to observe the request; got=%v", rt.requests)
+	}
+}
+
+func TestUpdateZone(t *testing.T) {
+	var requests []string
+	api := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+	})
+
+	if err := api.updateZone(&zone{ID: "zone1", Name: "example.com"}, 3600); err != nil {
+		t.Fatal(err)
+	}
+	if err := api.updateZone(&zone{Name: "example.net"}, 3600); err == nil {
+		t.Errorf("expected an error for a zone without ID")
+	}
+	if len(requests) != 1 || requests[0] != "PUT /zones/zone1" {
+		t.Errorf("expected a single PUT /zones/zone1; got=%v", requests)
+	}
+}
+
+func TestImportZoneFile(t *testing.T) {
+	zoneText := "$ORIGIN example.com.\n@ 3600 IN A 1.2.3.4\nwww 3600 IN CNAME @\n"
+	var body, contentType string
+	api := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/zones/zone1/import" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+		}
+		contentType = r.Header.Get("Content-Type")
+		data, _ := ioutil.ReadAll(r.Body)
+		body = string(data)
+	})
+	api.zones = map[string]zone{"example.com": {ID: "zone1", Name: "example.com"}}
+
+	if err := api.ImportZoneFile("example.com", zoneText); err != nil {
+		t.Fatal(err)
+	}
+	if body != zoneText {
+		t.Errorf("zone file was not sent unchanged; got=%q", body)
+	}
+	if contentType != "text/plain" {
+		t.Errorf("expected content type text/plain; got=%q", contentType)
+	}
+}
+
+func TestExportZoneFile(t *testing.T) {
+	zoneText := "$ORIGIN example.com.\n$TTL 7200\n@ IN SOA hydrogen.ns.hetzner.com. dns.hetzner.com. 2021031901 86400 10800 3600000 3600\n@ IN A 1.2.3.4\n"
+	api := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" || r.URL.Path != "/zones/zone1/export" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+		}
+		w.Header().Set("Content-Type", "text/plain")
+		fmt.Fprint(w, zoneText)
+	})
+	api.zones = map[string]zone{"example.com": {ID: "zone1", Name: "example.com"}}
+
+	got, err := api.ExportZoneFile("example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != zoneText {
+		t.Errorf("zone file was not returned unchanged; got=%q", got)
+	}
+}
+
+func TestRequest_errorContext(t *testing.T) {
+	api := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		fmt.Fprint(w, `{"error":{"message":"422 Unprocessable Entity: missing: ; ","code":422}}`)
+	})
+
+	err := api.deleteRecord(record{ID: "123"})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	for _, want := range []string{"HETZNER DELETE /records/123", "422", "missing: ;"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %q in the error; got=%q", want, err)
+		}
+	}
+	var apiErr *apiError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != 422 {
+		t.Errorf("expected a wrapped apiError; got=%#v", err)
+	}
+}
+
+func TestRequest_idempotencyKey(t *testing.T) {
+	var keys []string
+	api := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+		// Rate-limit the second write.
+		if len(keys) == 2 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	})
+	ttl := 300
+	a := record{Name: "www", TTL: &ttl, Type: "A", Value: "1.2.3.4", ZoneID: "zone1"}
+
+	b := a
+	b.Value = "5.6.7.8"
+
+	for _, r := range []record{a, a, b} {
+		if err := api.createRecord(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := api.deleteRecord(record{ID: "1", Type: "A"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(keys) != 5 {
+		t.Fatalf("expected 5 requests; got=%d", len(keys))
+	}
+	if keys[0] == "" || keys[0] != keys[1] || keys[1] != keys[2] {
+		t.Errorf("expected identical writes, retried or not, to have identical keys; got=%q", keys[:3])
+	}
+	if keys[3] == "" || keys[3] == keys[0] {
+		t.Errorf("expected a different write to have a different key; got=%q and %q", keys[3], keys[0])
+	}
+	if keys[4] != "" {
+		t.Errorf("expected no key for a deletion; got=%q", keys[4])
+	}
+}
+
+func TestRequest_logRequest(t *testing.T) {
+	api := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "DELETE" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+		fmt.Fprint(w, `{"records":[]}`)
+	})
+	var entries []requestLog
+	api.logRequest = func(entry requestLog) {
+		entries = append(entries, entry)
+	}
+
+	if _, err := api.getAllRecordsInZone(&zone{ID: "zone1", Name: "example.com"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := api.deleteRecord(record{ID: "123"}); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if len(entries) != 2 {
+		t.Fatalf("expected one entry per request; got=%+v", entries)
+	}
+	for i, want := range []requestLog{
+		{Method: "GET", Path: "/records?zone_id=zone1&per_page=100&page=1", StatusCode: 200},
+		{Method: "DELETE", Path: "/records/123", StatusCode: 404},
+	} {
+		got := entries[i]
+		if got.Method != want.Method || got.Path != want.Path || got.StatusCode != want.StatusCode || got.Duration <= 0 {
+			t.Errorf("%d: expected %+v; got=%+v", i, want, got)
+		}
+	}
+}
+
+func TestRequest_emptyBody(t *testing.T) {
+	api := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	response := &getZoneResponse{}
+	if err := api.request("/zones/zone1", "GET", nil, response); err != nil {
+		t.Errorf("unexpected error for an empty body: %v", err)
+	}
+	if response.Zone.ID != "" {
+		t.Errorf("expected the target to be left alone; got=%+v", response)
+	}
+}
+
+func TestRequest_oversizedBody(t *testing.T) {
+	api := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+		fmt.Fprint(w, strings.Repeat("x", 2*maxResponseSize))
+	})
+
+	err := api.deleteRecord(record{ID: "123"})
+	var apiErr *apiError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected a wrapped apiError; got=%v", err)
+	}
+	if apiErr.StatusCode != http.StatusBadGateway {
+		t.Errorf("expected status 502; got=%d", apiErr.StatusCode)
+	}
+	if len(apiErr.Message) != maxResponseSize {
+		t.Errorf("expected the body to be read up to %d bytes; got=%d", maxResponseSize, len(apiErr.Message))
+	}
+}
+
+func TestRequest_oversizedSuccessBody(t *testing.T) {
+	api := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, strings.Repeat("x", maxResponseSize+1))
+	})
+
+	// A truncated zone file would lose records when imported again.
+	if _, err := api.exportZoneFile("zone1"); err == nil || !strings.Contains(err.Error(), "exceeds") {
+		t.Errorf("expected an error for an oversized body; got=%v", err)
+	}
+}
+
+func TestGetAllRecordsInZone_progress(t *testing.T) {
+	api := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		fmt.Fprintf(w, `{"records":[{"id":"%s","name":"www","type":"A","value":"1.2.3.4","ttl":300,"zone_id":"zone1"}],
+			"meta":{"pagination":{"page":%s,"per_page":1,"last_page":2,"total_entries":2}}}`, page, page)
+	})
+
+	var out bytes.Buffer
+	old := printer.DefaultPrinter
+	printer.DefaultPrinter = &printer.ConsolePrinter{Writer: &out}
+	defer func() { printer.DefaultPrinter = old }()
+
+	records, err := api.getAllRecordsInZone(&zone{ID: "zone1", Name: "example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 {
+		t.Errorf("expected 2 records; got=%d", len(records))
+	}
+	if want := "HETZNER: fetching 2 records of \"example.com\" in 2 pages\n"; out.String() != want {
+		t.Errorf("expected progress %q; got=%q", want, out.String())
+	}
+
+	// A single page is not worth reporting.
+	out.Reset()
+	api = newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"records":[],"meta":{"pagination":{"page":1,"per_page":100,"last_page":1,"total_entries":0}}}`)
+	})
+	if _, err := api.getAllRecordsInZone(&zone{ID: "zone1", Name: "example.com"}); err != nil {
+		t.Fatal(err)
+	}
+	if out.Len() != 0 {
+		t.Errorf("expected no progress for a single page; got=%q", out.String())
+	}
+}
+
+func TestGetAllRecordsInZone_maxPages(t *testing.T) {
+	requests := 0
+	api := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if got := r.URL.Query().Get("per_page"); got != "25" {
+			t.Errorf("expected 25 records per page; got=%q", got)
+		}
+		// Always claims that there is another page.
+		page := r.URL.Query().Get("page")
+		fmt.Fprintf(w, `{"records":[],"meta":{"pagination":{"page":%s,"per_page":25,"last_page":%s1,"total_entries":1000}}}`, page, page)
+	})
+	api.pageSize = 25
+	api.maxPages = 3
+
+	var out bytes.Buffer
+	old := printer.DefaultPrinter
+	printer.DefaultPrinter = &printer.ConsolePrinter{Writer: &out}
+	defer func() { printer.DefaultPrinter = old }()
+
+	_, err := api.getAllRecordsInZone(&zone{ID: "zone1", Name: "example.com"})
+	if err == nil || !strings.Contains(err.Error(), "more than 3 pages") {
+		t.Errorf("expected an error after 3 pages; got=%v", err)
+	}
+	if requests != 3 {
+		t.Errorf("expected 3 requests; got=%d", requests)
+	}
+
+	requests = 0
+	err = api.getAllZones()
+	if err == nil || !strings.Contains(err.Error(), "more than 3 pages") {
+		t.Errorf("expected an error after 3 pages of zones; got=%v", err)
+	}
+	if requests != 3 {
+		t.Errorf("expected 3 requests for zones; got=%d", requests)
+	}
+}
+
+func TestPagination(t *testing.T) {
+	response := &getAllRecordsResponse{}
+	data := `{"records":[],"meta":{"pagination":{"page":1,"per_page":100,"last_page":13,"total_entries":1234}}}`
+	if err := json.Unmarshal([]byte(data), response); err != nil {
+		t.Fatal(err)
+	}
+	if p := response.Meta.Pagination; p.TotalEntries != 1234 || p.LastPage != 13 || p.PerPage != 100 {
+		t.Errorf("unexpected pagination: %+v", p)
+	}
+}
+
+func TestReadOnly(t *testing.T) {
+	api := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("unexpected request in read-only mode: %s %s", r.Method, r.URL)
+		}
+		fmt.Fprint(w, `{"records":[]}`)
+	})
+	api.readOnly = true
+	api.zones = map[string]zone{"example.com": {ID: "zone1", Name: "example.com"}}
+	dc := &models.DomainConfig{
+		Name:    "example.com",
+		Records: models.Records{makeRC("www", "A", "1.2.3.4", 300)},
+	}
+
+	corrections, err := api.GetDomainCorrections(dc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(corrections) != 1 {
+		t.Fatalf("expected 1 correction; got=%d", len(corrections))
+	}
+	err = corrections[0].F()
+	if !errors.Is(err, errReadOnly) {
+		t.Errorf("expected a read-only error; got=%v", err)
+	}
+
+	for _, err := range []error{
+		api.createZone("example.net"),
+		api.deleteRecord(record{ID: "1", Type: "A"}),
+		api.updateRecord(record{ID: "1", Type: "A"}),
+		api.importZoneFile("zone1", ""),
+	} {
+		if !errors.Is(err, errReadOnly) {
+			t.Errorf("expected a read-only error; got=%v", err)
+		}
+	}
+}
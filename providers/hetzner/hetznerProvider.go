@@ -3,25 +3,50 @@ package hetzner
 import (
 	"encoding/json"
 	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/StackExchange/dnscontrol/v3/models"
 	"github.com/StackExchange/dnscontrol/v3/pkg/diff"
+	"github.com/StackExchange/dnscontrol/v3/pkg/printer"
 	"github.com/StackExchange/dnscontrol/v3/providers"
+	"github.com/miekg/dns/dnsutil"
 )
 
+// metaZoneTTL is the domain metadata key for the default TTL of the zone.
+const metaZoneTTL = "hetzner_zone_ttl"
+
+// metaIgnore is the record metadata key that leaves all records with the
+// label and type of the record alone.
+const metaIgnore = "hetzner_ignore"
+
+// metaFlattenAlias is the domain metadata key that enables replacing
+// ALIAS records with the A and AAAA records of their target.
+const metaFlattenAlias = "hetzner_flatten_alias"
+
 var features = providers.DocumentationNotes{
 	providers.DocCreateDomains:       providers.Can(),
 	providers.DocDualHost:            providers.Can(),
 	providers.DocOfficiallySupported: providers.Cannot(),
 	providers.CanGetZones:            providers.Can(),
-	providers.CanUseAlias:            providers.Cannot(),
+	providers.CanUseAlias:            providers.Can("Only if flattened to A and AAAA records, see the provider docs"),
 	providers.CanUseCAA:              providers.Can(),
+	providers.CanUseDHCID:            providers.Cannot(),
 	providers.CanUseDS:               providers.Cannot(),
+	providers.CanUseDSForChildren:    providers.Can(),
+	providers.CanUseHINFO:            providers.Can(),
+	providers.CanUseLOC:              providers.Cannot(),
 	providers.CanUsePTR:              providers.Cannot(),
+	providers.CanUseRP:               providers.Can(),
 	providers.CanUseSRV:              providers.Can(),
 	providers.CanUseSSHFP:            providers.Cannot(),
-	providers.CanUseTLSA:             providers.Cannot(),
+	providers.CanUseSVCB:             providers.Cannot(),
+	providers.CanUseHTTPS:            providers.Cannot(),
+	providers.CanUseTLSA:             providers.Can(),
 	providers.CanUseTXTMulti:         providers.Can(),
 }
 
@@ -29,15 +54,38 @@ func init() {
 	providers.RegisterDomainServiceProviderType("HETZNER", New, features)
 }
 
+// Option changes how the API handle created by NewWithOptions works.
+type Option func(api *hetznerProvider)
+
+// WithTransport makes the API handle send its requests through rt, e.g.
+// to trace them or to use a proxy.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(api *hetznerProvider) {
+		api.transport = rt
+	}
+}
+
 // New creates a new API handle.
-func New(settings map[string]string, _ json.RawMessage) (providers.DNSServiceProvider, error) {
+func New(settings map[string]string, metadata json.RawMessage) (providers.DNSServiceProvider, error) {
+	return NewWithOptions(settings, metadata)
+}
+
+// NewWithOptions is like New, but applies opts to the API handle.
+func NewWithOptions(settings map[string]string, _ json.RawMessage, opts ...Option) (providers.DNSServiceProvider, error) {
 	if settings["api_key"] == "" {
 		return nil, fmt.Errorf("missing HETZNER api_key")
 	}
+	if err := checkAPIKey(settings["api_key"]); err != nil {
+		return nil, err
+	}
 
 	api := &hetznerProvider{}
+	for _, opt := range opts {
+		opt(api)
+	}
 
 	api.apiKey = settings["api_key"]
+	api.baseURL = defaultBaseURL
 
 	if settings["rate_limited"] == "true" {
 		// backwards compatibility
@@ -47,15 +95,162 @@ func New(settings map[string]string, _ json.RawMessage) (providers.DNSServicePro
 		api.startRateLimited()
 	}
 
+	if ttl := settings["default_ttl"]; ttl != "" {
+		defaultTTL, err := strconv.ParseUint(ttl, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected value for default_ttl: %w", err)
+		}
+		api.defaultTTL = uint32(defaultTTL)
+	}
+
+	if tolerance := settings["ttl_tolerance"]; tolerance != "" {
+		n, err := strconv.ParseUint(tolerance, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected value for ttl_tolerance: %w", err)
+		}
+		api.ttlTolerance = uint32(n)
+	}
+
+	if nameservers := settings["nameservers"]; nameservers != "" {
+		for _, ns := range strings.Split(nameservers, ",") {
+			if ns = strings.TrimSpace(ns); ns != "" {
+				api.nameservers = append(api.nameservers, ns)
+			}
+		}
+	}
+
+	if maxChanges := settings["max_changes"]; maxChanges != "" {
+		n, err := strconv.Atoi(maxChanges)
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("unexpected value for max_changes: %q", maxChanges)
+		}
+		api.maxChanges = n
+	}
+	if settings["max_changes_override"] == "true" {
+		api.maxChangesOverride = true
+	}
+
+	if settings["log_requests"] == "true" {
+		api.logRequest = printRequestLog
+	}
+
+	if settings["read_only"] == "true" {
+		api.readOnly = true
+	}
+
+	if settings["no_create_zones"] == "true" {
+		api.noCreateZones = true
+	}
+
+	if settings["prune_only"] == "true" {
+		api.pruneOnly = true
+	}
+
+	if settings["show_payloads"] == "true" {
+		api.showPayloads = true
+	}
+
+	if settings["create_secondary_zones"] == "true" {
+		api.secondaryZones = true
+	}
+
+	if primaryServers := settings["primary_servers"]; primaryServers != "" {
+		for _, server := range strings.Split(primaryServers, ",") {
+			if server = strings.TrimSpace(server); server == "" {
+				continue
+			}
+			ps, err := parsePrimaryServer(server)
+			if err != nil {
+				return nil, err
+			}
+			api.primaryServers = append(api.primaryServers, ps)
+		}
+	}
+
+	switch onConflict := settings["on_conflict"]; onConflict {
+	case "", "skip", "update":
+		api.onConflict = onConflict
+	default:
+		return nil, fmt.Errorf("unexpected value for on_conflict: %q, expected skip or update", onConflict)
+	}
+
+	if pageSize := settings["page_size"]; pageSize != "" {
+		n, err := strconv.Atoi(pageSize)
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("unexpected value for page_size: %q", pageSize)
+		}
+		api.pageSize = n
+	}
+
+	if maxPages := settings["max_pages"]; maxPages != "" {
+		n, err := strconv.Atoi(maxPages)
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("unexpected value for max_pages: %q", maxPages)
+		}
+		api.maxPages = n
+	}
+
+	api.zonesConcurrency = 1
+	if concurrency := settings["get_zones_concurrency"]; concurrency != "" {
+		n, err := strconv.Atoi(concurrency)
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("unexpected value for get_zones_concurrency: %q", concurrency)
+		}
+		api.zonesConcurrency = n
+	}
+
+	api.concurrency = 1
+	if concurrency := settings["concurrency"]; concurrency != "" {
+		n, err := strconv.Atoi(concurrency)
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("unexpected value for concurrency: %q", concurrency)
+		}
+		api.concurrency = n
+	}
+
+	if concurrency := settings["delete_concurrency"]; concurrency != "" {
+		n, err := strconv.Atoi(concurrency)
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("unexpected value for delete_concurrency: %q", concurrency)
+		}
+		api.deleteConcurrency = n
+	}
+
 	quota := settings["optimize_for_rate_limit_quota"]
 	err := api.requestRateLimiter.setOptimizeForRateLimitQuota(quota)
 	if err != nil {
 		return nil, fmt.Errorf("unexpected value for optimize_for_rate_limit_quota: %w", err)
 	}
 
+	// Reject settings that contradict each other rather than guessing.
+	if api.pruneOnly && api.readOnly {
+		return nil, fmt.Errorf("HETZNER prune_only and read_only cannot be combined, prune_only deletes records")
+	}
+	if api.maxChangesOverride && api.maxChanges == 0 {
+		return nil, fmt.Errorf("HETZNER max_changes_override has no effect without max_changes")
+	}
+
 	return api, nil
 }
 
+// checkAPIKey rejects API keys that cannot possibly be valid, e.g. with
+// whitespace from copy and paste. It does not assume a length or an
+// alphabet, HETZNER may change the format of its tokens.
+func checkAPIKey(key string) error {
+	if key == "your-api-key" {
+		return fmt.Errorf("HETZNER api_key is the placeholder from the documentation")
+	}
+	for _, r := range key {
+		if r <= ' ' || r > '~' {
+			return fmt.Errorf("HETZNER api_key contains whitespace or other unexpected characters")
+		}
+	}
+	if strings.ContainsAny(key, `"'`) {
+		return fmt.Errorf("HETZNER api_key contains quotes")
+	}
+	return nil
+}
+
 // EnsureDomainExists creates the domain if it does not exist.
 func (api *hetznerProvider) EnsureDomainExists(domain string) error {
 	domains, err := api.ListZones()
@@ -69,7 +264,48 @@ func (api *hetznerProvider) EnsureDomainExists(domain string) error {
 		}
 	}
 
-	return api.createZone(domain)
+	if api.noCreateZones {
+		return fmt.Errorf("zone %q does not exist and auto-create is disabled", domain)
+	}
+
+	if err := api.createZone(domain); err != nil {
+		return err
+	}
+	return api.waitForZone(domain)
+}
+
+// zoneCreationPolls is how often the zones are listed after creating a
+// zone until it shows up, zoneCreationPollInterval apart.
+const zoneCreationPolls = 5
+
+var zoneCreationPollInterval = time.Second
+
+// waitForZone lists the zones until name is one of them. A new zone may
+// not be listed right away, and the corrections need its ID.
+func (api *hetznerProvider) waitForZone(name string) error {
+	for i := 0; i < zoneCreationPolls; i++ {
+		if i > 0 {
+			time.Sleep(zoneCreationPollInterval)
+		}
+		api.resetZones()
+		zones, err := api.cachedZones()
+		if err != nil {
+			return err
+		}
+		if _, ok := zones[name]; ok {
+			return nil
+		}
+	}
+	return fmt.Errorf("zone %q was created but is not listed by HETZNER yet, try again later", name)
+}
+
+// ExportZoneFile returns the zone as a BIND zone file.
+func (api *hetznerProvider) ExportZoneFile(domain string) (string, error) {
+	zone, err := api.getZone(domain)
+	if err != nil {
+		return "", err
+	}
+	return api.exportZoneFile(zone.ID)
 }
 
 // GetDomainCorrections returns the corrections for a domain.
@@ -83,114 +319,758 @@ func (api *hetznerProvider) GetDomainCorrections(dc *models.DomainConfig) ([]*mo
 	if err != nil {
 		return nil, err
 	}
-	domain := dc.Name
+
+	z, err := api.client().getZone(dc.Name)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkIsZoneReady(z); err != nil {
+		return nil, err
+	}
 
 	// Get existing records
-	existingRecords, err := api.GetZoneRecords(domain)
+	existingRecords, err := api.zoneRecords(z)
 	if err != nil {
 		return nil, err
 	}
 
-	// Normalize
-	models.PostProcessRecords(existingRecords)
+	// The corrections reuse the zone, it is resolved once per domain.
+	corrections, summary, err := api.getDomainCorrections(dc, existingRecords, z.TTL, func() (*zone, error) {
+		return z, nil
+	})
+	if err != nil {
+		return nil, err
+	}
 
-	differ := diff.New(dc)
-	_, create, del, modify, err := differ.IncrementalDiff(existingRecords)
+	if value := dc.Metadata[metaZoneTTL]; value != "" {
+		ttl, err := strconv.ParseUint(value, 10, 31)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected value for %s: %w", metaZoneTTL, err)
+		}
+		if int(ttl) != z.TTL {
+			corrections = append(corrections, &models.Correction{
+				Msg: fmt.Sprintf("Change the default TTL of zone %s from %d to %d", z.Name, z.TTL, ttl),
+				F: func() error {
+					return api.client().updateZone(z, int(ttl))
+				},
+			})
+		}
+	}
+
+	// Last, as it imports the zone as it is after the other corrections.
+	soa, err := api.soaCorrection(dc, z, summary)
 	if err != nil {
 		return nil, err
 	}
+	if soa != nil {
+		corrections = append(corrections, soa)
+	}
 
-	var corrections []*models.Correction
+	return corrections, nil
+}
 
-	zone, err := api.getZone(domain)
+// GetDomainCorrectionsAgainst returns the corrections for a domain,
+// diffing against the supplied records instead of the live zone.
+func (api *hetznerProvider) GetDomainCorrectionsAgainst(dc *models.DomainConfig, existingRecords models.Records) ([]*models.Correction, error) {
+	dc, err := dc.Copy()
 	if err != nil {
 		return nil, err
 	}
 
-	for _, m := range del {
-		record := m.Existing.Original.(*record)
+	err = dc.Punycode()
+	if err != nil {
+		return nil, err
+	}
+
+	// The zone is only looked up when the corrections are applied.
+	// This keeps the diff itself free of API calls.
+	corrections, _, err := api.getDomainCorrections(dc, existingRecords, 0, func() (*zone, error) {
+		return api.client().getZone(dc.Name)
+	})
+	return corrections, err
+}
+
+// flattenAliases replaces the ALIAS records of dc with A and AAAA records
+// for the addresses their targets resolve to now. HETZNER has no ALIAS
+// records, so this has to be enabled per domain with metaFlattenAlias.
+func (api *hetznerProvider) flattenAliases(dc *models.DomainConfig) error {
+	lookupIP := api.lookupIP
+	if lookupIP == nil {
+		lookupIP = net.LookupIP
+	}
+
+	var records models.Records
+	for _, rc := range dc.Records {
+		if rc.Type != "ALIAS" {
+			records = append(records, rc)
+			continue
+		}
+		if dc.Metadata[metaFlattenAlias] != "true" {
+			return fmt.Errorf("HETZNER does not support ALIAS records, set %s to \"true\" to flatten %s to A and AAAA records", metaFlattenAlias, rc.GetLabelFQDN())
+		}
+
+		target := rc.GetTargetField()
+		ips, err := lookupIP(strings.TrimSuffix(target, "."))
+		if err != nil {
+			return fmt.Errorf("cannot flatten ALIAS %s: %w", rc.GetLabelFQDN(), err)
+		}
+		if len(ips) == 0 {
+			return fmt.Errorf("cannot flatten ALIAS %s: %s has no addresses", rc.GetLabelFQDN(), target)
+		}
+		var addresses []string
+		for _, ip := range ips {
+			flat := &models.RecordConfig{Type: "A", TTL: rc.TTL, Metadata: rc.Metadata}
+			if ip.To4() == nil {
+				flat.Type = "AAAA"
+			}
+			flat.SetLabel(rc.GetLabel(), dc.Name)
+			flat.SetTarget(ip.String())
+			records = append(records, flat)
+			addresses = append(addresses, ip.String())
+		}
+		printer.Warnf("HETZNER: ALIAS %s flattened to %s. These records become stale when the addresses of %s change, until dnscontrol is run again.\n", rc.GetLabelFQDN(), strings.Join(addresses, ", "), target)
+	}
+	dc.Records = records
+	return nil
+}
+
+// getDomainCorrections diffs dc against existingRecords. getZone returns
+// the zone to write the records to, it is called when the corrections
+// are applied.
+// zoneTTL is the default TTL of the zone, 0 if it is not known.
+func (api *hetznerProvider) getDomainCorrections(dc *models.DomainConfig, existingRecords models.Records, zoneTTL int, getZone func() (*zone, error)) ([]*models.Correction, *diffSummary, error) {
+	domain := dc.Name
+
+	// Records without a TTL use the zone's default. Compare them with
+	// that, as it is the TTL they are read back with, but still send them
+	// without a TTL so that they follow changes of the zone's default.
+	usesZoneTTL := map[*models.RecordConfig]bool{}
+	for _, rc := range dc.Records {
+		switch {
+		case rc.TTL != 0:
+		case api.defaultTTL != 0:
+			rc.TTL = api.defaultTTL
+		case zoneTTL > 0:
+			rc.TTL = uint32(zoneTTL)
+			usesZoneTTL[rc] = true
+		}
+	}
+	toNative := func(rc *models.RecordConfig, zone *zone) *record {
+		record := fromRecordConfig(rc, zone)
+		if usesZoneTTL[rc] {
+			record.TTL = nil
+		}
+		return record
+	}
+
+	// With show_payloads, the preview shows the records as they are sent.
+	var payloadZone *zone
+	if api.showPayloads {
+		var err error
+		payloadZone, err = getZone()
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	describe := func(m diff.Correlation, id string) (string, error) {
+		if payloadZone == nil {
+			return m.String(), nil
+		}
+		record := toNative(m.Desired, payloadZone)
+		record.ID = id
+		payload, err := json.Marshal(record)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%s\n\tpayload: %s", m.String(), payload), nil
+	}
+
+	// SOA records are hidden when reading the zone, they must not be
+	// created or modified either.
+	dc.Filter(func(rc *models.RecordConfig) bool {
+		if rc.Type == "SOA" {
+			printer.Warnf("HETZNER: SOA records are managed by HETZNER. Skipping %s\n", rc.GetLabelFQDN())
+			return false
+		}
+		return true
+	})
+
+	if err := api.flattenAliases(dc); err != nil {
+		return nil, nil, err
+	}
+
+	// HETZNER rejects a CNAME at the apex, as it would conflict with the
+	// NS and SOA records there, but only with an opaque error.
+	for _, rc := range dc.Records {
+		if rc.Type == "CNAME" && rc.GetLabel() == "@" {
+			return nil, nil, fmt.Errorf("HETZNER: a CNAME is not allowed at the apex of %s (RFC 1912), use A and AAAA records instead, or an ALIAS with %s", domain, metaFlattenAlias)
+		}
+	}
+
+	// HETZNER rejects the conflicting records one by one, with an opaque
+	// error. The config is not validated when supplied to
+	// GetDomainCorrectionsAgainst.
+	if errs := dc.Records.CheckCNAMEs(); len(errs) > 0 {
+		msgs := make([]string, len(errs))
+		for i, err := range errs {
+			msgs[i] = err.Error()
+		}
+		return nil, nil, fmt.Errorf("HETZNER: %s", strings.Join(msgs, "; "))
+	}
+
+	// The differ refuses to touch ignored records. Name the rule each of
+	// them matches, its error does not.
+	ignored := diff.IgnoredDesired(dc)
+	for _, rc := range dc.Records {
+		if rule, ok := ignored[rc]; ok {
+			printer.Warnf("HETZNER: %s %s matches %s and cannot be managed\n", rc.Type, rc.GetLabelFQDN(), rule)
+		}
+	}
+
+	// Normalize. HETZNER may change the case of hostnames, and the config
+	// is not normalized when supplied to GetDomainCorrectionsAgainst.
+	models.PostProcessRecords(existingRecords)
+	models.PostProcessRecords(dc.Records)
+	for _, rc := range dc.Records {
+		if rc.Type == "TLSA" {
+			// HETZNER stores the certificate association in lower case.
+			rc.SetTarget(strings.ToLower(rc.GetTargetField()))
+		}
+		if rc.Type == "CAA" {
+			// The value may have been quoted in the config.
+			rc.SetTarget(models.StripQuotes(rc.GetTargetField()))
+		}
+	}
+
+	// Records flagged with hetzner_ignore are neither created nor deleted,
+	// and neither are the existing records of the same label and type.
+	ignoredKeys := map[models.RecordKey]bool{}
+	dc.Filter(func(rc *models.RecordConfig) bool {
+		if rc.Metadata[metaIgnore] == "true" {
+			ignoredKeys[rc.Key()] = true
+			return false
+		}
+		return true
+	})
+	if len(ignoredKeys) > 0 {
+		var managed models.Records
+		for _, rc := range existingRecords {
+			if !ignoredKeys[rc.Key()] {
+				managed = append(managed, rc)
+			}
+		}
+		existingRecords = managed
+	}
+
+	// NO_PURGE keeps the very records prune_only is meant to delete.
+	if api.pruneOnly && dc.KeepUnknown {
+		return nil, nil, fmt.Errorf("HETZNER prune_only cannot be used for %s, it has NO_PURGE", domain)
+	}
+
+	// HETZNER may quote and split TXT records differently than the config.
+	differ := diff.NewDecodingTXT(dc)
+	_, create, del, modify, err := differ.IncrementalDiff(existingRecords)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// With NO_PURGE nothing is ever deleted. The differ only leaves out
+	// record sets that are absent from the config; surplus records in
+	// a managed set would otherwise still be removed.
+	if dc.KeepUnknown {
+		del = nil
+		if err := checkKeptConflicts(existingRecords, create, modify); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// In prune-only mode, records missing from the config are deleted but
+	// nothing is created or modified.
+	if api.pruneOnly {
+		create, modify = nil, nil
+	}
+
+	// A small change of the TTL alone is not worth an API call.
+	if api.ttlTolerance > 0 {
+		var significant diff.Changeset
+		for _, m := range modify {
+			if isTTLOnlyChange(m, api.ttlTolerance) {
+				continue
+			}
+			significant = append(significant, m)
+		}
+		modify = significant
+	}
+
+	// HETZNER rejects invalid CAA and TLSA records with a generic error.
+	for _, m := range append(append(diff.Changeset{}, create...), modify...) {
+		if err := checkCAA(m.Desired); err != nil {
+			return nil, nil, err
+		}
+		if err := checkTLSA(m.Desired); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var corrections []*models.Correction
+
+	// A bad config must not wipe the zone.
+	if changes := len(create) + len(modify) + len(del); api.maxChanges > 0 && changes > api.maxChanges && !api.maxChangesOverride {
+		return nil, nil, fmt.Errorf("HETZNER: %d changes to %s (%d deletions) exceed max_changes of %d, set max_changes_override to apply them anyway", changes, domain, len(del), api.maxChanges)
+	}
+
+	if api.parallelDeletes(len(del)) > 1 {
+		// There is no bulk delete, send the deletions in parallel instead.
+		deleteRecords := make([]record, len(del))
+		deleteDescription := []string{"Batch deletion of records:"}
+		for i, m := range del {
+			deleteRecords[i] = *m.Existing.Original.(*record)
+			deleteDescription = append(deleteDescription, m.String())
+		}
 		corr := &models.Correction{
-			Msg: m.String(),
+			Msg: strings.Join(deleteDescription, "\n\t"),
 			F: func() error {
-				return api.deleteRecord(*record)
+				return api.client().deleteRecords(deleteRecords)
 			},
 		}
 		corrections = append(corrections, corr)
+	} else {
+		for _, m := range del {
+			record := m.Existing.Original.(*record)
+			corr := &models.Correction{
+				Msg: m.String(),
+				F: func() error {
+					return api.client().deleteRecord(*record)
+				},
+			}
+			corrections = append(corrections, corr)
+		}
 	}
 
-	var createRecords []record
+	deletions := len(corrections)
+
+	var createRecords []*models.RecordConfig
 	createDescription := []string{"Batch creation of records:"}
 	for _, m := range create {
-		record := fromRecordConfig(m.Desired, zone)
-		createRecords = append(createRecords, *record)
-		createDescription = append(createDescription, m.String())
+		description, err := describe(m, "")
+		if err != nil {
+			return nil, nil, err
+		}
+		createRecords = append(createRecords, m.Desired)
+		createDescription = append(createDescription, description)
 	}
 	if len(createRecords) > 0 {
 		corr := &models.Correction{
 			Msg: strings.Join(createDescription, "\n\t"),
 			F: func() error {
-				return api.bulkCreateRecords(createRecords)
+				zone, err := getZone()
+				if err != nil {
+					return err
+				}
+				records := make([]record, len(createRecords))
+				for i, rc := range createRecords {
+					records[i] = *toNative(rc, zone)
+				}
+				err = api.client().bulkCreateRecords(records)
+				if isConflict(err) && api.onConflict != "" {
+					return api.resolveConflict(zone, records)
+				}
+				return err
 			},
 		}
 		corrections = append(corrections, corr)
 	}
 
-	var modifyRecords []record
+	var modifyRecords []*models.RecordConfig
+	var modifyIDs []string
 	modifyDescription := []string{"Batch modification of records:"}
 	for _, m := range modify {
+		if err := checkSameRecord(m.Existing.Original.(*record), m.Desired, domain); err != nil {
+			return nil, nil, err
+		}
 		id := m.Existing.Original.(*record).ID
-		record := fromRecordConfig(m.Desired, zone)
-		record.ID = id
-		modifyRecords = append(modifyRecords, *record)
-		modifyDescription = append(modifyDescription, m.String())
+		description, err := describe(m, id)
+		if err != nil {
+			return nil, nil, err
+		}
+		modifyRecords = append(modifyRecords, m.Desired)
+		modifyIDs = append(modifyIDs, id)
+		modifyDescription = append(modifyDescription, description)
 	}
 	if len(modifyRecords) > 0 {
 		corr := &models.Correction{
 			Msg: strings.Join(modifyDescription, "\n\t"),
 			F: func() error {
-				return api.bulkUpdateRecords(modifyRecords)
+				zone, err := getZone()
+				if err != nil {
+					return err
+				}
+				records := make([]record, len(modifyRecords))
+				for i, rc := range modifyRecords {
+					records[i] = *toNative(rc, zone)
+					records[i].ID = modifyIDs[i]
+				}
+				return api.client().bulkUpdateRecords(records)
 			},
 		}
 		corrections = append(corrections, corr)
 	}
 
-	return corrections, nil
+	if len(corrections) > 0 {
+		printer.Printf("HETZNER: %s: %s\n", domain, summarizeChanges(create, modify, del))
+	}
+
+	if api.concurrency > 1 && len(corrections) > 1 {
+		// All deletions must be done before records are created, a label
+		// may be reused by a record of another type.
+		corrections = api.applyConcurrently(corrections[:deletions], corrections[deletions:])
+	}
+
+	return corrections, newDiffSummary(create, modify, del), nil
+}
+
+// checkSameRecord makes sure that the existing record, whose ID is reused
+// to modify it, has the label and type of the desired record. Otherwise
+// another record would be overwritten.
+func checkSameRecord(existing *record, desired *models.RecordConfig, domain string) error {
+	label := dnsutil.AddOrigin(existing.Name, domain)
+	if !strings.EqualFold(label, desired.GetLabelFQDN()) || existing.Type != desired.Type {
+		return fmt.Errorf("HETZNER: refusing to modify record %s (%s %s) into %s %s, it is a different record", existing.ID, existing.Type, label, desired.Type, desired.GetLabelFQDN())
+	}
+	return nil
+}
+
+// resolveConflict creates records after bulkCreateRecords failed because
+// some of them already exist, e.g. because another process created them
+// after the zone was read. Depending on onConflict, those are skipped or
+// updated in place.
+func (api *hetznerProvider) resolveConflict(zone *zone, records []record) error {
+	existing, err := api.client().getAllRecordsInZone(zone)
+	if err != nil {
+		return err
+	}
+	ids := map[string]string{}
+	for _, r := range existing {
+		ids[r.Name+" "+r.Type+" "+r.Value] = r.ID
+	}
+
+	var create, update []record
+	for _, r := range records {
+		id, ok := ids[r.Name+" "+r.Type+" "+r.Value]
+		switch {
+		case !ok:
+			create = append(create, r)
+		case api.onConflict == "update":
+			r.ID = id
+			update = append(update, r)
+		default:
+			printer.Warnf("HETZNER: %s record %s already exists in %s. Skipping it\n", r.Type, r.Name, zone.Name)
+		}
+	}
+
+	if len(create) > 0 {
+		if err := api.client().bulkCreateRecords(create); err != nil {
+			return err
+		}
+	}
+	if len(update) > 0 {
+		return api.client().bulkUpdateRecords(update)
+	}
+	return nil
+}
+
+// applyConcurrently returns a correction for each phase that applies its
+// corrections using up to concurrency parallel workers. All of them are
+// applied even if some fail, the error names each that failed. A phase is
+// not applied if the previous one failed.
+func (api *hetznerProvider) applyConcurrently(phases ...[]*models.Correction) []*models.Correction {
+	var corrections []*models.Correction
+	failed := false
+	for _, phase := range phases {
+		if len(phase) == 0 {
+			continue
+		}
+		phase := phase
+		description := make([]string, len(phase))
+		for i, c := range phase {
+			description[i] = c.Msg
+		}
+		corrections = append(corrections, &models.Correction{
+			Msg: strings.Join(description, "\n"),
+			F: func() error {
+				if failed {
+					return fmt.Errorf("not applied, as the previous corrections failed")
+				}
+				pool := newAdaptivePool(api.concurrency)
+				errs := pool.run(len(phase), api.requestRateLimiter.rateLimitedCount, func(i int) error {
+					return phase[i].F()
+				})
+				var msgs []string
+				for i, err := range errs {
+					if err != nil {
+						msgs = append(msgs, fmt.Sprintf("%s: %s", strings.SplitN(phase[i].Msg, "\n", 2)[0], err))
+					}
+				}
+				if len(msgs) > 0 {
+					failed = true
+					return fmt.Errorf("%d of %d corrections failed:\n\t%s", len(msgs), len(phase), strings.Join(msgs, "\n\t"))
+				}
+				return nil
+			},
+		})
+	}
+	return corrections
+}
+
+// newDiffSummary returns the summary of the changes for a domain.
+func newDiffSummary(create, modify, del diff.Changeset) *diffSummary {
+	summary := &diffSummary{}
+	for _, c := range create {
+		summary.creates = append(summary.creates, c.Desired)
+	}
+	for _, c := range modify {
+		summary.updates = append(summary.updates, c.Desired)
+	}
+	for _, c := range del {
+		summary.deletes = append(summary.deletes, c.Existing)
+	}
+	return summary
+}
+
+// summarizeChanges returns the number of records to create, modify and
+// delete, broken down by type,
+// e.g. "2 to create (A: 1, MX: 1), 0 to modify, 1 to delete (TXT: 1)".
+func summarizeChanges(create, modify, del diff.Changeset) string {
+	summarize := func(changes diff.Changeset, verb string, rtype func(diff.Correlation) string) string {
+		counts := map[string]int{}
+		for _, c := range changes {
+			counts[rtype(c)]++
+		}
+		if len(counts) == 0 {
+			return fmt.Sprintf("0 to %s", verb)
+		}
+		var types []string
+		for t := range counts {
+			types = append(types, t)
+		}
+		sort.Strings(types)
+		for i, t := range types {
+			types[i] = fmt.Sprintf("%s: %d", t, counts[t])
+		}
+		return fmt.Sprintf("%d to %s (%s)", len(changes), verb, strings.Join(types, ", "))
+	}
+	desiredType := func(c diff.Correlation) string { return c.Desired.Type }
+	existingType := func(c diff.Correlation) string { return c.Existing.Type }
+	return strings.Join([]string{
+		summarize(create, "create", desiredType),
+		summarize(modify, "modify", desiredType),
+		summarize(del, "delete", existingType),
+	}, ", ")
+}
+
+// checkKeptConflicts returns an error if a record to create would share its
+// label with a CNAME record, or be a CNAME record sharing its label with
+// another record, that is kept because of NO_PURGE. HETZNER would reject it.
+func checkKeptConflicts(existing models.Records, create, modify diff.Changeset) error {
+	modified := map[*models.RecordConfig]bool{}
+	for _, m := range modify {
+		modified[m.Existing] = true
+	}
+	kept := map[string][]*models.RecordConfig{}
+	for _, rc := range existing {
+		if !modified[rc] {
+			label := strings.ToLower(rc.GetLabelFQDN())
+			kept[label] = append(kept[label], rc)
+		}
+	}
+	for _, c := range create {
+		for _, rc := range kept[strings.ToLower(c.Desired.GetLabelFQDN())] {
+			if c.Desired.Type == "CNAME" || rc.Type == "CNAME" {
+				return fmt.Errorf("HETZNER: cannot create %s %s, NO_PURGE keeps the %s record with the same name", c.Desired.Type, c.Desired.GetLabelFQDN(), rc.Type)
+			}
+		}
+	}
+	return nil
+}
+
+// isTTLOnlyChange reports whether m changes nothing but the TTL, and
+// that by at most tolerance seconds.
+func isTTLOnlyChange(m diff.Correlation, tolerance uint32) bool {
+	if m.Existing.GetTargetCombined() != m.Desired.GetTargetCombined() {
+		return false
+	}
+	a, b := m.Existing.TTL, m.Desired.TTL
+	if a < b {
+		a, b = b, a
+	}
+	return a-b <= tolerance
 }
 
 // GetNameservers returns the nameservers for a domain.
 func (api *hetznerProvider) GetNameservers(domain string) ([]*models.Nameserver, error) {
-	zone, err := api.getZone(domain)
-	if err != nil {
-		return nil, err
+	reported := api.nameservers
+	if len(reported) == 0 {
+		zone, err := api.getZone(domain)
+		if err != nil {
+			return nil, err
+		}
+		reported = zone.NameServers
 	}
-	nameserver := make([]*models.Nameserver, len(zone.NameServers))
-	for i := range zone.NameServers {
-		nameserver[i] = &models.Nameserver{Name: zone.NameServers[i]}
+	// The API returns them in any order and not always with a trailing dot.
+	nameservers := make([]string, len(reported))
+	for i, ns := range reported {
+		nameservers[i] = strings.TrimSuffix(ns, ".")
 	}
-	return nameserver, nil
+	sort.Strings(nameservers)
+	return models.ToNameservers(nameservers)
 }
 
 // GetZoneRecords gets the records of a zone and returns them in RecordConfig format.
 func (api *hetznerProvider) GetZoneRecords(domain string) (models.Records, error) {
-	records, err := api.getAllRecords(domain)
+	zone, err := api.getZone(domain)
+	if err != nil {
+		return nil, err
+	}
+	return api.zoneRecords(zone)
+}
+
+// zoneRecords gets the records of zone and returns them in RecordConfig format.
+func (api *hetznerProvider) zoneRecords(zone *zone) (models.Records, error) {
+	records, err := api.client().getAllRecordsInZone(zone)
 	if err != nil {
 		return nil, err
 	}
 	existingRecords := make([]*models.RecordConfig, len(records))
 	for i := range records {
-		existingRecords[i] = toRecordConfig(domain, &records[i])
+		existingRecords[i] = toRecordConfig(zone.Name, &records[i])
 	}
+	// The API does not guarantee any order. Sort, so previews are stable.
+	sortRecords(existingRecords)
 	return existingRecords, nil
 }
 
+// GetZoneRecordsNative returns the records of a zone as stored by HETZNER,
+// including the IDs and timestamps that RecordConfig has no room for.
+func (api *hetznerProvider) GetZoneRecordsNative(domain string) ([]Record, error) {
+	zone, err := api.getZone(domain)
+	if err != nil {
+		return nil, err
+	}
+	records, err := api.client().getAllRecordsInZone(zone)
+	if err != nil {
+		return nil, err
+	}
+	native := make([]Record, len(records))
+	for i := range records {
+		native[i] = records[i].toRecord()
+	}
+	sort.SliceStable(native, func(i, j int) bool {
+		a, b := native[i], native[j]
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		return a.Value < b.Value
+	})
+	return native, nil
+}
+
+// GetZoneRecordsModifiedSince returns the records of a zone that were
+// created or modified after since. Records without a valid modification
+// time are always returned.
+func (api *hetznerProvider) GetZoneRecordsModifiedSince(domain string, since time.Time) (models.Records, error) {
+	records, err := api.GetZoneRecords(domain)
+	if err != nil {
+		return nil, err
+	}
+	var modified models.Records
+	for _, rc := range records {
+		if t, err := rc.Original.(*record).modifiedAt(); err == nil && !t.After(since) {
+			continue
+		}
+		modified = append(modified, rc)
+	}
+	return modified, nil
+}
+
+// sortRecords sorts records by label, type and target.
+func sortRecords(records models.Records) {
+	sort.SliceStable(records, func(i, j int) bool {
+		a, b := records[i], records[j]
+		if a.GetLabelFQDN() != b.GetLabelFQDN() {
+			return a.GetLabelFQDN() < b.GetLabelFQDN()
+		}
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		return a.GetTargetCombined() < b.GetTargetCombined()
+	})
+}
+
+// GetZonesRecords gets the records of many zones, fetching up to
+// get_zones_concurrency zones in parallel. Fewer zones are fetched in
+// parallel while HETZNER rate-limits the requests.
+func (api *hetznerProvider) GetZonesRecords(domains []string) ([]models.Records, error) {
+	// Populate the zone cache before the workers start sharing it.
+	if err := api.getAllZones(); err != nil {
+		return nil, err
+	}
+
+	results := make([]models.Records, len(domains))
+	pool := newAdaptivePool(api.zonesConcurrency)
+	errs := pool.run(len(domains), api.requestRateLimiter.rateLimitedCount, func(i int) (err error) {
+		results[i], err = api.GetZoneRecords(domains[i])
+		return err
+	})
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return results, nil
+}
+
+// ImportZoneFile replaces the records of a zone with those of a BIND zone file.
+func (api *hetznerProvider) ImportZoneFile(domain string, zoneText string) error {
+	zone, err := api.getZone(domain)
+	if err != nil {
+		return err
+	}
+	return api.importZoneFile(zone.ID, zoneText)
+}
+
 // ListZones lists the zones on this account.
 func (api *hetznerProvider) ListZones() ([]string, error) {
-	if err := api.getAllZones(); err != nil {
+	cached, err := api.cachedZones()
+	if err != nil {
 		return nil, err
 	}
 	var zones []string
-	for i := range api.zones {
+	for i := range cached {
 		zones = append(zones, i)
 	}
+	sort.Strings(zones)
 	return zones, nil
 }
+
+// parsePrimaryServer parses "address" or "address:port", the port
+// defaults to 53. IPv6 addresses with a port must be in brackets.
+func parsePrimaryServer(s string) (PrimaryServer, error) {
+	host, port, err := net.SplitHostPort(s)
+	if err != nil {
+		return PrimaryServer{Address: strings.Trim(s, "[]"), Port: 53}, nil
+	}
+	n, err := strconv.Atoi(port)
+	if err != nil || n < 1 || n > 65535 {
+		return PrimaryServer{}, fmt.Errorf("unexpected value for primary_servers: %q", s)
+	}
+	return PrimaryServer{Address: host, Port: n}, nil
+}
+
+// VerifyCredentials checks that the API accepts the api_key.
+func (api *hetznerProvider) VerifyCredentials() error {
+	return api.request("/zones?per_page=1", "GET", nil, nil)
+}
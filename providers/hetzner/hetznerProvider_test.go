@@ -0,0 +1,1782 @@
+package hetzner
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/StackExchange/dnscontrol/v3/models"
+	"github.com/StackExchange/dnscontrol/v3/pkg/printer"
+)
+
+// newTestProvider returns a provider that talks to a local server using handler.
+func newTestProvider(t *testing.T, handler http.HandlerFunc) *hetznerProvider {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Keep the rate-limiter from slowing down the tests.
+		w.Header().Set("X-Ratelimit-Limit-Second", "1000")
+		handler(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	api := &hetznerProvider{apiKey: "test", baseURL: server.URL}
+	api.requestRateLimiter.setOptimizeForRateLimitQuota("second")
+	return api
+}
+
+func makeRC(label, rtype, target string, ttl uint32) *models.RecordConfig {
+	rc := &models.RecordConfig{Type: rtype, TTL: ttl}
+	rc.SetLabel(label, "example.com")
+	rc.PopulateFromString(rtype, target, "example.com")
+	return rc
+}
+
+func makeExisting(id, name, rtype, value string, ttl int) *models.RecordConfig {
+	return toRecordConfig("example.com", &record{
+		ID:     id,
+		Name:   name,
+		TTL:    &ttl,
+		Type:   rtype,
+		Value:  value,
+		ZoneID: "zone1",
+	})
+}
+
+// fakeClient serves canned zones and records and remembers the changes.
+type fakeClient struct {
+	zones   map[string]zone
+	records map[string][]record // By zone ID.
+
+	created, updated, deleted []record
+
+	createErrs map[string]error // By record type.
+
+	zoneFiles map[string]string // By zone ID.
+}
+
+func (c *fakeClient) bulkCreateRecords(records []record) error {
+	for _, r := range records {
+		if err := c.createErrs[r.Type]; err != nil {
+			return err
+		}
+	}
+	c.created = append(c.created, records...)
+	for _, r := range records {
+		r.ID = fmt.Sprintf("new%d", len(c.created))
+		c.records[r.ZoneID] = append(c.records[r.ZoneID], r)
+	}
+	return nil
+}
+
+func (c *fakeClient) bulkUpdateRecords(records []record) error {
+	c.updated = append(c.updated, records...)
+	return nil
+}
+
+func (c *fakeClient) deleteRecord(record record) error {
+	c.deleted = append(c.deleted, record)
+	return nil
+}
+
+func (c *fakeClient) deleteRecords(records []record) error {
+	c.deleted = append(c.deleted, records...)
+	return nil
+}
+
+func (c *fakeClient) exportZoneFile(zoneID string) (string, error) {
+	zoneText, ok := c.zoneFiles[zoneID]
+	if !ok {
+		return "", fmt.Errorf("unexpected export of zone %q", zoneID)
+	}
+	// The records created since the last import are part of the zone.
+	for _, r := range c.records[zoneID] {
+		zoneText += fmt.Sprintf("%s\t%d\tIN\t%s\t%s\n", r.Name, *r.TTL, r.Type, r.Value)
+	}
+	return zoneText, nil
+}
+
+func (c *fakeClient) importZoneFile(zoneID string, zoneText string) error {
+	if c.zoneFiles == nil {
+		c.zoneFiles = map[string]string{}
+	}
+	c.zoneFiles[zoneID] = zoneText
+	delete(c.records, zoneID)
+	return nil
+}
+
+func (c *fakeClient) getAllRecordsInZone(zone *zone) ([]record, error) {
+	return c.records[zone.ID], nil
+}
+
+func (c *fakeClient) getZone(name string) (*zone, error) {
+	zone, ok := c.zones[name]
+	if !ok {
+		return nil, fmt.Errorf("%q is not a zone", name)
+	}
+	return &zone, nil
+}
+
+func (c *fakeClient) updateZone(zone *zone, ttl int) error {
+	return fmt.Errorf("unexpected update of zone %q", zone.Name)
+}
+
+func TestGetDomainCorrections_fakeClient(t *testing.T) {
+	ttl := 300
+	client := &fakeClient{
+		zones: map[string]zone{"example.com": {ID: "zone1", Name: "example.com"}},
+		records: map[string][]record{"zone1": {
+			{ID: "1", Name: "www", Type: "A", Value: "1.2.3.4", TTL: &ttl, ZoneID: "zone1"},
+			{ID: "2", Name: "mail", Type: "A", Value: "9.9.9.9", TTL: &ttl, ZoneID: "zone1"},
+			{ID: "3", Name: "old", Type: "A", Value: "10.0.0.1", TTL: &ttl, ZoneID: "zone1"},
+		}},
+	}
+	api := &hetznerProvider{fakeClient: client}
+	dc := &models.DomainConfig{
+		Name: "example.com",
+		Records: models.Records{
+			makeRC("www", "A", "1.2.3.4", 300),
+			makeRC("mail", "A", "5.6.7.8", 300),
+			makeRC("new", "TXT", "hello", 300),
+		},
+	}
+
+	corrections, err := api.GetDomainCorrections(dc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range corrections {
+		if err := c.F(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(client.deleted) != 1 || client.deleted[0].ID != "3" {
+		t.Errorf("expected record 3 to be deleted; got=%+v", client.deleted)
+	}
+	if len(client.created) != 1 || client.created[0].Name != "new" || client.created[0].ZoneID != "zone1" {
+		t.Errorf("expected the TXT record to be created in zone1; got=%+v", client.created)
+	}
+	if len(client.updated) != 1 || client.updated[0].ID != "2" || client.updated[0].Value != "5.6.7.8" {
+		t.Errorf("expected record 2 to be updated; got=%+v", client.updated)
+	}
+}
+
+func TestGetDomainCorrections_onConflict(t *testing.T) {
+	ttl := 300
+	for _, test := range []struct {
+		onConflict string
+		updated    int
+	}{
+		{"", 0},
+		{"skip", 0},
+		{"update", 1},
+	} {
+		// www was created by another process after the zone was read.
+		client := &fakeClient{
+			zones: map[string]zone{"example.com": {ID: "zone1", Name: "example.com"}},
+			records: map[string][]record{"zone1": {
+				{ID: "1", Name: "www", Type: "A", Value: "1.2.3.4", TTL: &ttl, ZoneID: "zone1"},
+			}},
+			createErrs: map[string]error{"A": fmt.Errorf("HETZNER POST /records/bulk: %w", &apiError{StatusCode: 422, Message: "record already exists"})},
+		}
+		api := &hetznerProvider{fakeClient: client, onConflict: test.onConflict}
+		dc := &models.DomainConfig{
+			Name: "example.com",
+			Records: models.Records{
+				makeRC("www", "A", "1.2.3.4", 600),
+				makeRC("new", "TXT", "hello", 300),
+			},
+		}
+
+		corrections, err := api.GetDomainCorrectionsAgainst(dc, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(corrections) != 1 {
+			t.Fatalf("%q: expected 1 correction; got=%d", test.onConflict, len(corrections))
+		}
+		err = corrections[0].F()
+		if test.onConflict == "" {
+			if !isConflict(err) {
+				t.Errorf("expected the conflict to be returned; got=%v", err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%q: %v", test.onConflict, err)
+		}
+		if len(client.created) != 1 || client.created[0].Name != "new" {
+			t.Errorf("%q: expected only the TXT record to be created; got=%+v", test.onConflict, client.created)
+		}
+		if len(client.updated) != test.updated {
+			t.Errorf("%q: expected %d updated records; got=%+v", test.onConflict, test.updated, client.updated)
+		}
+		if test.updated > 0 && (client.updated[0].ID != "1" || *client.updated[0].TTL != 600) {
+			t.Errorf("%q: expected record 1 to be updated to TTL 600; got=%+v", test.onConflict, client.updated[0])
+		}
+	}
+
+	if _, err := New(map[string]string{"api_key": "test", "on_conflict": "ignore"}, nil); err == nil {
+		t.Errorf("expected an error for an unknown on_conflict")
+	}
+}
+
+func TestGetDomainCorrectionsAgainst(t *testing.T) {
+	api := &hetznerProvider{}
+	dc := &models.DomainConfig{
+		Name: "example.com",
+		Records: models.Records{
+			makeRC("www", "A", "1.2.3.4", 300),
+			makeRC("mail", "A", "5.6.7.8", 300),
+		},
+	}
+	existing := models.Records{
+		makeExisting("1", "www", "A", "1.2.3.4", 300),
+		makeExisting("2", "mail", "A", "9.9.9.9", 300),
+		makeExisting("3", "old", "A", "10.0.0.1", 300),
+	}
+
+	corrections, err := api.GetDomainCorrectionsAgainst(dc, existing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(corrections) != 2 {
+		t.Fatalf("expected 2 corrections; got=%d", len(corrections))
+	}
+	if !strings.HasPrefix(corrections[0].Msg, "DELETE A old.example.com") {
+		t.Errorf("expected a deletion first; got=%q", corrections[0].Msg)
+	}
+	if !strings.Contains(corrections[1].Msg, "MODIFY A mail.example.com") {
+		t.Errorf("expected a modification; got=%q", corrections[1].Msg)
+	}
+}
+
+func TestGetDomainCorrectionsAgainst_summary(t *testing.T) {
+	api := &hetznerProvider{}
+	dc := &models.DomainConfig{
+		Name: "example.com",
+		Records: models.Records{
+			makeRC("www", "A", "1.2.3.4", 300),
+			makeRC("mail", "A", "5.6.7.8", 300),
+			makeRC("new", "A", "10.0.0.2", 300),
+			makeRC("@", "MX", "10 mail.example.com.", 300),
+		},
+	}
+	existing := models.Records{
+		makeExisting("1", "www", "A", "1.2.3.4", 300),
+		makeExisting("2", "mail", "A", "9.9.9.9", 300),
+		makeExisting("3", "old", "A", "10.0.0.1", 300),
+		makeExisting("4", "old", "TXT", "hello", 300),
+	}
+
+	var out bytes.Buffer
+	old := printer.DefaultPrinter
+	printer.DefaultPrinter = &printer.ConsolePrinter{Writer: &out}
+	defer func() { printer.DefaultPrinter = old }()
+
+	if _, err := api.GetDomainCorrectionsAgainst(dc, existing); err != nil {
+		t.Fatal(err)
+	}
+	want := "HETZNER: example.com: 2 to create (A: 1, MX: 1), 1 to modify (A: 1), 2 to delete (A: 1, TXT: 1)\n"
+	if out.String() != want {
+		t.Errorf("expected summary %q; got=%q", want, out.String())
+	}
+
+	// Nothing to do, nothing to summarize.
+	out.Reset()
+	dc = &models.DomainConfig{Name: "example.com", Records: models.Records{makeRC("www", "A", "1.2.3.4", 300)}}
+	if _, err := api.GetDomainCorrectionsAgainst(dc, models.Records{makeExisting("1", "www", "A", "1.2.3.4", 300)}); err != nil {
+		t.Fatal(err)
+	}
+	if out.Len() != 0 {
+		t.Errorf("expected no summary without changes; got=%q", out.String())
+	}
+}
+
+func TestGetDomainCorrectionsAgainst_invalidCAA(t *testing.T) {
+	api := &hetznerProvider{}
+	for _, test := range []struct {
+		value, field string
+	}{
+		{`0 issuer "letsencrypt.org"`, `tag "issuer"`},
+	} {
+		dc := &models.DomainConfig{
+			Name:    "example.com",
+			Records: models.Records{makeRC("@", "CAA", test.value, 300)},
+		}
+		_, err := api.GetDomainCorrectionsAgainst(dc, nil)
+		if err == nil || !strings.Contains(err.Error(), test.field) {
+			t.Errorf("expected an error naming the %s; got=%v", test.field, err)
+		}
+	}
+
+	dc := &models.DomainConfig{
+		Name:    "example.com",
+		Records: models.Records{makeRC("@", "CAA", `0 issue "letsencrypt.org"`, 300)},
+	}
+	if _, err := api.GetDomainCorrectionsAgainst(dc, nil); err != nil {
+		t.Errorf("unexpected error for a valid CAA record: %v", err)
+	}
+}
+
+func TestGetDomainCorrectionsAgainst_invalidTLSA(t *testing.T) {
+	api := &hetznerProvider{}
+	for _, test := range []struct {
+		value, problem string
+	}{
+		{"3 1 1 not-hex", "expected hex digits"},
+		{"3 1 1 0b9fa5a5", "4 bytes, expected 32"},
+		{"4 1 1 0b9fa5a59eed715c26c1020c711b4f6ec42d58b0015e14337a39dad301c5afc3", "invalid usage 4"},
+	} {
+		dc := &models.DomainConfig{
+			Name:    "example.com",
+			Records: models.Records{makeRC("_443._tcp", "TLSA", test.value, 300)},
+		}
+		_, err := api.GetDomainCorrectionsAgainst(dc, nil)
+		if err == nil || !strings.Contains(err.Error(), test.problem) {
+			t.Errorf("%s: expected an error containing %q; got=%v", test.value, test.problem, err)
+		}
+	}
+
+	// Differently cased hex is not a change.
+	dc := &models.DomainConfig{
+		Name:    "example.com",
+		Records: models.Records{makeRC("_443._tcp", "TLSA", "3 1 1 0B9FA5A59EED715C26C1020C711B4F6EC42D58B0015E14337A39DAD301C5AFC3", 300)},
+	}
+	existing := models.Records{makeExisting("1", "_443._tcp", "TLSA", "3 1 1 0b9fa5a59eed715c26c1020c711b4f6ec42d58b0015e14337a39dad301c5afc3", 300)}
+	corrections, err := api.GetDomainCorrectionsAgainst(dc, existing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range corrections {
+		t.Errorf("unexpected correction: %q", c.Msg)
+	}
+}
+
+func TestGetDomainCorrectionsAgainst_maxChanges(t *testing.T) {
+	api := &hetznerProvider{maxChanges: 2}
+	dc := &models.DomainConfig{
+		Name:    "example.com",
+		Records: models.Records{makeRC("www", "A", "1.2.3.4", 300)},
+	}
+	existing := models.Records{
+		makeExisting("1", "www", "A", "1.2.3.4", 300),
+		makeExisting("2", "mail", "A", "5.6.7.8", 300),
+		makeExisting("3", "ftp", "A", "5.6.7.9", 300),
+		makeExisting("4", "old", "TXT", "hello", 300),
+	}
+
+	_, err := api.GetDomainCorrectionsAgainst(dc, existing)
+	if err == nil || !strings.Contains(err.Error(), "3 changes to example.com (3 deletions) exceed max_changes of 2") {
+		t.Errorf("expected the deletions to be refused; got=%v", err)
+	}
+
+	api.maxChangesOverride = true
+	corrections, err := api.GetDomainCorrectionsAgainst(dc, existing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(corrections) != 3 {
+		t.Errorf("expected 3 corrections with the override; got=%d", len(corrections))
+	}
+}
+
+func TestGetDomainCorrectionsAgainst_noPurge(t *testing.T) {
+	api := &hetznerProvider{}
+	dc := &models.DomainConfig{
+		Name:        "example.com",
+		KeepUnknown: true,
+		Records: models.Records{
+			makeRC("www", "A", "1.2.3.4", 300),
+			makeRC("new", "A", "5.6.7.8", 300),
+		},
+	}
+	existing := models.Records{
+		makeExisting("1", "www", "A", "1.2.3.4", 300),
+		makeExisting("2", "www", "A", "4.3.2.1", 300),
+		makeExisting("3", "unmanaged", "A", "10.0.0.1", 300),
+		makeExisting("4", "unmanaged", "TXT", "hello", 300),
+	}
+
+	corrections, err := api.GetDomainCorrectionsAgainst(dc, existing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(corrections) != 1 {
+		t.Fatalf("expected 1 correction; got=%d", len(corrections))
+	}
+	for _, c := range corrections {
+		if strings.Contains(c.Msg, "DELETE") {
+			t.Errorf("unexpected deletion with NO_PURGE: %q", c.Msg)
+		}
+	}
+}
+
+func TestGetDomainCorrectionsAgainst_noPurgeTypeChange(t *testing.T) {
+	api := &hetznerProvider{}
+	dc := &models.DomainConfig{
+		Name:        "example.com",
+		KeepUnknown: true,
+		Records:     models.Records{makeRC("www", "CNAME", "example.net.", 300)},
+	}
+	existing := models.Records{
+		makeExisting("1", "www", "A", "1.2.3.4", 300),
+		makeExisting("2", "unmanaged", "A", "10.0.0.1", 300),
+	}
+
+	// The A record is not deleted to make room for the CNAME record.
+	_, err := api.GetDomainCorrectionsAgainst(dc, existing)
+	if err == nil || !strings.Contains(err.Error(), "NO_PURGE keeps the A record") {
+		t.Errorf("expected the CNAME record to conflict with the kept A record; got=%v", err)
+	}
+}
+
+func TestGetDomainCorrectionsAgainst_ttlTolerance(t *testing.T) {
+	api := &hetznerProvider{ttlTolerance: 60}
+	dc := &models.DomainConfig{
+		Name: "example.com",
+		Records: models.Records{
+			makeRC("www", "A", "1.2.3.4", 330),
+			makeRC("mail", "A", "5.6.7.8", 600),
+			makeRC("ftp", "A", "10.0.0.2", 330),
+		},
+	}
+	existing := models.Records{
+		makeExisting("1", "www", "A", "1.2.3.4", 300),
+		makeExisting("2", "mail", "A", "5.6.7.8", 300),
+		makeExisting("3", "ftp", "A", "10.0.0.1", 300),
+	}
+
+	corrections, err := api.GetDomainCorrectionsAgainst(dc, existing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(corrections) != 1 {
+		t.Fatalf("expected 1 correction; got=%d", len(corrections))
+	}
+	if strings.Contains(corrections[0].Msg, "www.example.com") {
+		t.Errorf("a TTL change within the tolerance should be dropped; got=%q", corrections[0].Msg)
+	}
+	for _, want := range []string{"mail.example.com", "ftp.example.com"} {
+		if !strings.Contains(corrections[0].Msg, want) {
+			t.Errorf("expected %s to be modified; got=%q", want, corrections[0].Msg)
+		}
+	}
+}
+
+func TestGetDomainCorrectionsAgainst_hostnameCase(t *testing.T) {
+	api := &hetznerProvider{}
+	dc := &models.DomainConfig{
+		Name: "example.com",
+		Records: models.Records{
+			makeRC("@", "MX", "10 mail.example.com.", 300),
+			makeRC("www", "CNAME", "Web.Example.COM.", 300),
+			makeRC("_sip._tcp", "SRV", "10 60 5060 sip.example.com.", 300),
+		},
+	}
+	existing := models.Records{
+		makeExisting("1", "@", "MX", "10 Mail.Example.COM.", 300),
+		makeExisting("2", "www", "CNAME", "web.example.com.", 300),
+		makeExisting("3", "_sip._tcp", "SRV", "10 60 5060 SIP.example.com.", 300),
+	}
+
+	corrections, err := api.GetDomainCorrectionsAgainst(dc, existing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range corrections {
+		t.Errorf("hostnames differing in case only should not be changed; got=%q", c.Msg)
+	}
+}
+
+func TestGetDomainCorrectionsAgainst_flattenAlias(t *testing.T) {
+	api := &hetznerProvider{}
+	api.lookupIP = func(host string) ([]net.IP, error) {
+		if host != "lb.example.net" {
+			t.Errorf("unexpected lookup of %q", host)
+		}
+		return []net.IP{net.ParseIP("192.0.2.1"), net.ParseIP("192.0.2.2"), net.ParseIP("2001:db8::1")}, nil
+	}
+	alias := makeRC("@", "ALIAS", "", 300)
+	alias.SetTarget("lb.example.net.")
+	dc := &models.DomainConfig{Name: "example.com", Records: models.Records{alias}}
+	existing := models.Records{makeExisting("1", "@", "A", "192.0.2.1", 300)}
+
+	// Flattening must be enabled for the domain.
+	if _, err := api.GetDomainCorrectionsAgainst(dc, existing); err == nil || !strings.Contains(err.Error(), metaFlattenAlias) {
+		t.Errorf("expected an error mentioning %s; got=%v", metaFlattenAlias, err)
+	}
+
+	dc.Metadata = map[string]string{metaFlattenAlias: "true"}
+	corrections, err := api.GetDomainCorrectionsAgainst(dc, existing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var msgs []string
+	for _, c := range corrections {
+		msgs = append(msgs, c.Msg)
+	}
+	got := strings.Join(msgs, "\n")
+	if len(corrections) != 1 || !strings.Contains(got, "192.0.2.2") || !strings.Contains(got, "2001:db8::1") || strings.Contains(got, "ALIAS") {
+		t.Errorf("expected the missing A and AAAA records to be created; got=%q", got)
+	}
+}
+
+func TestGetDomainCorrectionsAgainst_apexCNAME(t *testing.T) {
+	api := &hetznerProvider{}
+	dc := &models.DomainConfig{
+		Name:    "example.com",
+		Records: models.Records{makeRC("@", "CNAME", "web.example.net.", 300)},
+	}
+
+	_, err := api.GetDomainCorrectionsAgainst(dc, nil)
+	if err == nil || !strings.Contains(err.Error(), "apex of example.com") || !strings.Contains(err.Error(), "ALIAS") {
+		t.Errorf("expected an error suggesting ALIAS instead of a CNAME at the apex; got=%v", err)
+	}
+}
+
+func TestGetDomainCorrectionsAgainst_ignoreMetadata(t *testing.T) {
+	api := &hetznerProvider{}
+	ignoredTXT := makeRC("@", "TXT", "google-site-verification=new", 300)
+	ignoredTXT.Metadata = map[string]string{metaIgnore: "true"}
+	ignoredA := makeRC("new", "A", "1.2.3.4", 300)
+	ignoredA.Metadata = map[string]string{metaIgnore: "true"}
+	dc := &models.DomainConfig{
+		Name:    "example.com",
+		Records: models.Records{ignoredTXT, ignoredA, makeRC("www", "A", "1.2.3.4", 300)},
+	}
+	existing := models.Records{
+		makeExisting("1", "@", "TXT", "google-site-verification=old", 300),
+		makeExisting("2", "www", "A", "1.2.3.4", 300),
+	}
+
+	corrections, err := api.GetDomainCorrectionsAgainst(dc, existing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range corrections {
+		t.Errorf("ignored records should be neither created nor deleted; got=%q", c.Msg)
+	}
+}
+
+func TestGetDomainCorrectionsAgainst_mismatchedOriginal(t *testing.T) {
+	api := &hetznerProvider{}
+	dc := &models.DomainConfig{
+		Name:    "example.com",
+		Records: models.Records{makeRC("www", "A", "5.6.7.8", 300)},
+	}
+	existing := makeExisting("1", "www", "A", "1.2.3.4", 300)
+	// The pairing is right, the ID may be reused.
+	if _, err := api.GetDomainCorrectionsAgainst(dc, models.Records{existing}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The record read from HETZNER is another one than the RecordConfig.
+	existing.Original.(*record).Name = "mail"
+	_, err := api.GetDomainCorrectionsAgainst(dc, models.Records{existing})
+	if err == nil || !strings.Contains(err.Error(), "refusing to modify record 1") {
+		t.Errorf("expected the mismatched record to be caught; got=%v", err)
+	}
+}
+
+func TestGetDomainCorrectionsAgainst_pruneOnly(t *testing.T) {
+	api := &hetznerProvider{pruneOnly: true}
+	dc := &models.DomainConfig{
+		Name: "example.com",
+		Records: models.Records{
+			makeRC("new", "A", "1.2.3.4", 300),
+			makeRC("www", "A", "5.6.7.8", 300),
+			makeRC("mail", "CNAME", "mx.example.net.", 300),
+		},
+	}
+	existing := models.Records{
+		makeExisting("1", "www", "A", "1.2.3.4", 300),
+		makeExisting("2", "mail", "A", "1.2.3.4", 300),
+		makeExisting("3", "stray", "A", "1.2.3.4", 300),
+	}
+
+	corrections, err := api.GetDomainCorrectionsAgainst(dc, existing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var msgs []string
+	for _, c := range corrections {
+		msgs = append(msgs, c.Msg)
+	}
+	// The A record at mail is not in the config either, only a CNAME.
+	if got := strings.Join(msgs, "\n"); len(msgs) != 2 || !strings.HasPrefix(msgs[0], "DELETE A mail.example.com") || !strings.HasPrefix(msgs[1], "DELETE A stray.example.com") {
+		t.Errorf("expected only the records missing from the config to be deleted; got=%q", got)
+	}
+}
+
+func TestGetDomainCorrectionsAgainst_pruneOnlyNoPurge(t *testing.T) {
+	api := &hetznerProvider{pruneOnly: true}
+	dc := &models.DomainConfig{Name: "example.com", KeepUnknown: true}
+	existing := models.Records{makeExisting("1", "stray", "A", "1.2.3.4", 300)}
+
+	_, err := api.GetDomainCorrectionsAgainst(dc, existing)
+	if err == nil || !strings.Contains(err.Error(), "NO_PURGE") {
+		t.Errorf("expected prune_only to be refused with NO_PURGE; got=%v", err)
+	}
+}
+
+func TestGetDomainCorrectionsAgainst_cnameConflict(t *testing.T) {
+	api := &hetznerProvider{}
+	dc := &models.DomainConfig{
+		Name: "example.com",
+		Records: models.Records{
+			makeRC("www", "CNAME", "web.example.net.", 300),
+			makeRC("www", "A", "1.2.3.4", 300),
+		},
+	}
+
+	_, err := api.GetDomainCorrectionsAgainst(dc, nil)
+	if err == nil || !strings.Contains(err.Error(), "cannot have CNAME and A record with same name: www.example.com") {
+		t.Errorf("expected an error for the CNAME and A at www; got=%v", err)
+	}
+}
+
+func TestGetDomainCorrectionsAgainst_txtQuoting(t *testing.T) {
+	api := &hetznerProvider{}
+	dc := &models.DomainConfig{
+		Name:    "example.com",
+		Records: models.Records{makeRC("_dmarc", "TXT", "v=DMARC1; p=none", 300)},
+	}
+	existing := models.Records{makeExisting("1", "_dmarc", "TXT", `"v=DMARC1; " "p=none"`, 300)}
+
+	corrections, err := api.GetDomainCorrectionsAgainst(dc, existing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range corrections {
+		t.Errorf("TXT records differing in quoting only should not be changed; got=%q", c.Msg)
+	}
+}
+
+func TestGetDomainCorrectionsAgainst_trailingDot(t *testing.T) {
+	api := &hetznerProvider{}
+	dc := &models.DomainConfig{
+		Name:    "example.com",
+		Records: models.Records{makeRC("www", "CNAME", "web.example.com.", 300)},
+	}
+	existing := models.Records{makeExisting("1", "www", "CNAME", "web.example.com", 300)}
+
+	corrections, err := api.GetDomainCorrectionsAgainst(dc, existing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range corrections {
+		t.Errorf("a target without the trailing dot should match the config; got=%q", c.Msg)
+	}
+}
+
+func TestToRecordConfig_relativeTargets(t *testing.T) {
+	tests := []struct {
+		rtype, value string
+		target       string
+	}{
+		{"CNAME", "web.example.com.", "web.example.com."},
+		{"CNAME", "web.example.com", "web.example.com."},
+		{"CNAME", "www", "www.example.com."},
+		{"CNAME", "mail.sub", "mail.sub.example.com."},
+		{"CNAME", "@", "example.com."},
+		{"NS", "ns1.example.net.", "ns1.example.net."},
+		{"MX", "10 mail.sub", "mail.sub.example.com."},
+		{"MX", "0 .", "."},
+		{"SRV", "10 60 5060 sip.example.com", "sip.example.com."},
+	}
+	for _, test := range tests {
+		rc := makeExisting("1", "www", test.rtype, test.value, 300)
+		if rc.GetTargetField() != test.target {
+			t.Errorf("%s %q: expected target %q; got=%q", test.rtype, test.value, test.target, rc.GetTargetField())
+		}
+	}
+}
+
+func TestGetDomainCorrections_zoneResolvedOnce(t *testing.T) {
+	requests := map[string]int{}
+	var created string
+	api := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		requests[r.Method+" "+r.URL.Path]++
+		switch r.URL.Path {
+		case "/zones":
+			fmt.Fprint(w, `{"zones":[{"id":"zone1","name":"example.com","ttl":3600}]}`)
+		case "/records":
+			fmt.Fprint(w, `{"records":[{"id":"1","name":"www","type":"A","value":"1.2.3.4","ttl":300,"zone_id":"zone1"}]}`)
+		case "/records/bulk":
+			data, _ := ioutil.ReadAll(r.Body)
+			created = string(data)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+		}
+	})
+	dc := &models.DomainConfig{
+		Name: "example.com",
+		Records: models.Records{
+			makeRC("www", "A", "1.2.3.4", 300),
+			makeRC("mail", "A", "5.6.7.8", 300),
+		},
+	}
+
+	corrections, err := api.GetDomainCorrections(dc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range corrections {
+		if err := c.F(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if requests["GET /zones"] != 1 {
+		t.Errorf("expected the zone to be resolved once; got=%v", requests)
+	}
+	if len(requests) != 3 || requests["GET /records"] != 1 || requests["POST /records/bulk"] != 1 {
+		t.Errorf("unexpected requests: %v", requests)
+	}
+	if !strings.Contains(created, `"zone_id":"zone1"`) {
+		t.Errorf("expected the records to be created in zone1; got=%s", created)
+	}
+}
+
+func TestGetDomainCorrectionsAgainst_concurrency(t *testing.T) {
+	var mu sync.Mutex
+	var requests []string
+	api := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "DELETE" {
+			// Slow deletions must still be done before the creation.
+			time.Sleep(20 * time.Millisecond)
+		}
+		mu.Lock()
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		mu.Unlock()
+	})
+	api.concurrency = 4
+	api.zones = map[string]zone{"example.com": {ID: "zone1", Name: "example.com"}}
+	dc := &models.DomainConfig{
+		Name: "example.com",
+		Records: models.Records{
+			makeRC("www", "CNAME", "web.example.com.", 300),
+			makeRC("mail", "A", "5.6.7.8", 300),
+		},
+	}
+	existing := models.Records{
+		makeExisting("1", "www", "A", "1.2.3.4", 300),
+		makeExisting("2", "www", "A", "1.2.3.5", 300),
+		makeExisting("3", "old", "A", "10.0.0.1", 300),
+		makeExisting("4", "mail", "A", "9.9.9.9", 300),
+	}
+
+	corrections, err := api.GetDomainCorrectionsAgainst(dc, existing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(corrections) != 2 {
+		t.Fatalf("expected a correction for the deletions and one for the other changes; got=%d", len(corrections))
+	}
+	for _, c := range corrections {
+		if err := c.F(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(requests) != 5 {
+		t.Fatalf("expected 3 deletions, a creation and a modification; got=%v", requests)
+	}
+	for i, r := range requests {
+		if deletion := strings.HasPrefix(r, "DELETE"); deletion != (i < 3) {
+			t.Errorf("expected the deletions before the other changes; got=%v", requests)
+			break
+		}
+	}
+}
+
+func TestGetDomainCorrectionsAgainst_concurrencyErrors(t *testing.T) {
+	var mu sync.Mutex
+	var requests []string
+	api := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		mu.Unlock()
+		if r.URL.Path == "/records/2" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+	api.concurrency = 4
+	api.zones = map[string]zone{"example.com": {ID: "zone1", Name: "example.com"}}
+	dc := &models.DomainConfig{
+		Name:    "example.com",
+		Records: models.Records{makeRC("new", "A", "5.6.7.8", 300)},
+	}
+	existing := models.Records{
+		makeExisting("1", "old1", "A", "10.0.0.1", 300),
+		makeExisting("2", "old2", "A", "10.0.0.2", 300),
+		makeExisting("3", "old3", "A", "10.0.0.3", 300),
+	}
+
+	corrections, err := api.GetDomainCorrectionsAgainst(dc, existing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(corrections) != 2 {
+		t.Fatalf("expected 2 corrections; got=%d", len(corrections))
+	}
+
+	// The other deletions are still applied, the error names the failed one.
+	err = corrections[0].F()
+	if err == nil || !strings.Contains(err.Error(), "1 of 3 corrections failed") || !strings.Contains(err.Error(), "old2.example.com") || strings.Contains(err.Error(), "old1") {
+		t.Errorf("expected an error for the deletion of old2 only; got=%v", err)
+	}
+	if len(requests) != 3 {
+		t.Errorf("expected all 3 deletions to be sent; got=%v", requests)
+	}
+
+	// Nothing is created while a record may still be in the way.
+	if err := corrections[1].F(); err == nil || !strings.Contains(err.Error(), "previous corrections failed") {
+		t.Errorf("expected the creation not to be applied; got=%v", err)
+	}
+	if len(requests) != 3 {
+		t.Errorf("unexpected requests: %v", requests)
+	}
+}
+
+func TestGetDomainCorrections_zoneTTLChange(t *testing.T) {
+	var body string
+	api := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /records":
+			fmt.Fprint(w, `{"records":[]}`)
+		case "PUT /zones/zone1":
+			data, _ := ioutil.ReadAll(r.Body)
+			body = string(data)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+		}
+	})
+	api.zones = map[string]zone{"example.com": {ID: "zone1", Name: "example.com", TTL: 86400}}
+	dc := &models.DomainConfig{
+		Name:     "example.com",
+		Metadata: map[string]string{"hetzner_zone_ttl": "3600"},
+	}
+
+	corrections, err := api.GetDomainCorrections(dc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(corrections) != 1 || !strings.Contains(corrections[0].Msg, "from 86400 to 3600") {
+		t.Fatalf("expected a correction of the zone TTL; got=%v", corrections)
+	}
+	if err := corrections[0].F(); err != nil {
+		t.Fatal(err)
+	}
+	if body != `{"name":"example.com","ttl":3600}` {
+		t.Errorf("unexpected payload: %s", body)
+	}
+
+	// An unchanged TTL needs no correction.
+	dc.Metadata["hetzner_zone_ttl"] = "86400"
+	corrections, err = api.GetDomainCorrections(dc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(corrections) != 0 {
+		t.Errorf("expected no corrections; got=%d", len(corrections))
+	}
+}
+
+func TestGetDomainCorrections_soaRefresh(t *testing.T) {
+	client := &fakeClient{
+		zones:   map[string]zone{"example.com": {ID: "zone1", Name: "example.com", TTL: 86400}},
+		records: map[string][]record{},
+		zoneFiles: map[string]string{"zone1": `$ORIGIN example.com.
+$TTL 86400
+@	IN	SOA	hydrogen.ns.hetzner.com. dns.hetzner.com. 2021040101 86400 10800 3600000 3600
+@	IN	NS	hydrogen.ns.hetzner.com.
+www	300	IN	A	1.2.3.4
+`},
+	}
+	api := &hetznerProvider{fakeClient: client}
+	dc := &models.DomainConfig{
+		Name:     "example.com",
+		Metadata: map[string]string{metaSOARefresh: "43200", metaSOARetry: "10800"},
+	}
+
+	corrections, err := api.GetDomainCorrections(dc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(corrections) != 1 || !strings.Contains(corrections[0].Msg, "refresh 86400 -> 43200") || strings.Contains(corrections[0].Msg, "retry") {
+		t.Fatalf("expected a correction of the SOA refresh interval; got=%v", corrections)
+	}
+	if err := corrections[0].F(); err != nil {
+		t.Fatal(err)
+	}
+	zoneText := client.zoneFiles["zone1"]
+	if !strings.Contains(zoneText, "2021040101 43200 10800 3600000 3600") {
+		t.Errorf("expected the refresh interval to change in the imported zone; got=%s", zoneText)
+	}
+	if !strings.Contains(zoneText, "www.example.com.\t300\tIN\tA\t1.2.3.4") {
+		t.Errorf("expected the other records to be imported unchanged; got=%s", zoneText)
+	}
+
+	// Now the SOA record matches.
+	corrections, err = api.GetDomainCorrections(dc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(corrections) != 0 {
+		t.Errorf("expected no corrections; got=%d", len(corrections))
+	}
+}
+
+func TestGetDomainCorrections_soaWithRecordChanges(t *testing.T) {
+	client := &fakeClient{
+		zones:   map[string]zone{"example.com": {ID: "zone1", Name: "example.com", TTL: 86400}},
+		records: map[string][]record{},
+		zoneFiles: map[string]string{"zone1": `$ORIGIN example.com.
+$TTL 86400
+@	IN	SOA	hydrogen.ns.hetzner.com. dns.hetzner.com. 2021040101 86400 10800 3600000 3600
+@	IN	NS	hydrogen.ns.hetzner.com.
+`},
+	}
+	api := &hetznerProvider{fakeClient: client}
+	dc := &models.DomainConfig{
+		Name:     "example.com",
+		Metadata: map[string]string{metaSOARefresh: "43200"},
+		Records:  models.Records{makeRC("mail", "A", "1.2.3.5", 300)},
+	}
+
+	corrections, err := api.GetDomainCorrections(dc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(corrections) != 2 || !strings.Contains(corrections[1].Msg, "SOA") {
+		t.Fatalf("expected the creation of mail and then the SOA change; got=%v", corrections)
+	}
+	for _, c := range corrections {
+		if err := c.F(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	zoneText := client.zoneFiles["zone1"]
+	if !strings.Contains(zoneText, "2021040101 43200 10800 3600000 3600") {
+		t.Errorf("expected the refresh interval to change in the imported zone; got=%s", zoneText)
+	}
+	if !strings.Contains(zoneText, "mail.example.com.\t300\tIN\tA\t1.2.3.5") {
+		t.Errorf("expected the created record to survive the import; got=%s", zoneText)
+	}
+}
+
+func TestGetDomainCorrections_soaConcurrentChange(t *testing.T) {
+	zoneText := `$ORIGIN example.com.
+$TTL 86400
+@	IN	SOA	hydrogen.ns.hetzner.com. dns.hetzner.com. 2021040101 86400 10800 3600000 3600
+@	IN	NS	hydrogen.ns.hetzner.com.
+`
+	client := &fakeClient{
+		zones:     map[string]zone{"example.com": {ID: "zone1", Name: "example.com", TTL: 86400}},
+		records:   map[string][]record{},
+		zoneFiles: map[string]string{"zone1": zoneText},
+	}
+	api := &hetznerProvider{fakeClient: client}
+	dc := &models.DomainConfig{
+		Name:     "example.com",
+		Metadata: map[string]string{metaSOARefresh: "43200"},
+	}
+
+	corrections, err := api.GetDomainCorrections(dc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(corrections) != 1 || !strings.Contains(corrections[0].Msg, "imports the whole zone") {
+		t.Fatalf("expected the SOA change; got=%v", corrections)
+	}
+
+	// Someone else creates a record between the preview and the push.
+	ttl := 300
+	client.records["zone1"] = []record{{ID: "9", Name: "other", Type: "A", Value: "10.0.0.1", TTL: &ttl, ZoneID: "zone1"}}
+	if err := corrections[0].F(); err == nil || !strings.Contains(err.Error(), "3 records instead of the expected 2") {
+		t.Errorf("expected the import to be refused; got=%v", err)
+	}
+	if client.zoneFiles["zone1"] != zoneText {
+		t.Errorf("expected the zone not to be imported; got=%s", client.zoneFiles["zone1"])
+	}
+}
+
+func TestGetDomainCorrections_zoneDefaultTTL(t *testing.T) {
+	zoneTTL := 86400
+	client := &fakeClient{
+		zones: map[string]zone{"example.com": {ID: "zone1", Name: "example.com", TTL: zoneTTL}},
+		records: map[string][]record{"zone1": {
+			// Read back with the zone's default, as HETZNER reports no TTL.
+			{ID: "1", Name: "www", Type: "A", Value: "1.2.3.4", TTL: &zoneTTL, ZoneID: "zone1"},
+		}},
+	}
+	api := &hetznerProvider{fakeClient: client}
+	dc := &models.DomainConfig{
+		Name: "example.com",
+		Records: models.Records{
+			makeRC("www", "A", "1.2.3.4", 0),
+			makeRC("mail", "A", "1.2.3.5", 0),
+		},
+	}
+
+	corrections, err := api.GetDomainCorrections(dc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(corrections) != 1 {
+		t.Fatalf("expected only the creation of mail; got=%v", corrections)
+	}
+	if err := corrections[0].F(); err != nil {
+		t.Fatal(err)
+	}
+	if len(client.created) != 1 || client.created[0].Name != "mail" {
+		t.Fatalf("expected mail to be created; got=%v", client.created)
+	}
+	if client.created[0].TTL != nil {
+		t.Errorf("expected mail to be created without a TTL; got=%d", *client.created[0].TTL)
+	}
+}
+
+func TestGetDomainCorrections_summary(t *testing.T) {
+	api := &hetznerProvider{}
+	existing := models.Records{
+		makeExisting("1", "www", "A", "1.2.3.4", 300),
+		makeExisting("2", "old", "TXT", "stale", 300),
+	}
+	dc := &models.DomainConfig{
+		Name: "example.com",
+		Records: models.Records{
+			makeRC("www", "A", "5.6.7.8", 300),
+			makeRC("mail", "A", "1.2.3.5", 300),
+			makeRC("@", "MX", "mail.example.com.", 300),
+		},
+	}
+	dc.Records[2].MxPreference = 10
+
+	corrections, summary, err := api.getDomainCorrections(dc, existing, 0, func() (*zone, error) {
+		return &zone{ID: "zone1", Name: "example.com"}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	creates, updates, deletes := summary.counts()
+	if creates != 2 || updates != 1 || deletes != 1 {
+		t.Errorf("expected 2 creates, 1 update and 1 delete; got=%d, %d and %d", creates, updates, deletes)
+	}
+	if summary.updates[0].GetTargetField() != "5.6.7.8" || summary.deletes[0].GetLabel() != "old" {
+		t.Errorf("unexpected summary: %+v", summary)
+	}
+
+	// Each change is one line of the corrections, batches have a heading.
+	changes := 0
+	for _, c := range corrections {
+		lines := strings.Split(c.Msg, "\n")
+		if strings.HasPrefix(lines[0], "Batch ") {
+			lines = lines[1:]
+		}
+		changes += len(lines)
+	}
+	if changes != creates+updates+deletes {
+		t.Errorf("expected the summary to match the %d changes of the corrections; got=%d", changes, creates+updates+deletes)
+	}
+}
+
+func TestToRecordConfig_noTTL(t *testing.T) {
+	rc := toRecordConfig("example.com", &record{ID: "1", Name: "www", Type: "A", Value: "1.2.3.4", ZoneID: "zone1"})
+	if rc.TTL != 0 || rc.GetTargetField() != "1.2.3.4" {
+		t.Errorf("expected a record without a TTL; got=%+v", rc)
+	}
+}
+
+func TestGetDomainCorrections_showPayloads(t *testing.T) {
+	ttl := 300
+	client := &fakeClient{
+		zones: map[string]zone{"example.com": {ID: "zone1", Name: "example.com", TTL: 86400}},
+		records: map[string][]record{"zone1": {
+			{ID: "1", Name: "www", Type: "A", Value: "1.2.3.4", TTL: &ttl, ZoneID: "zone1"},
+		}},
+	}
+	api := &hetznerProvider{fakeClient: client, showPayloads: true}
+	dc := &models.DomainConfig{
+		Name: "example.com",
+		Records: models.Records{
+			makeRC("www", "A", "5.6.7.8", 300),
+			makeRC("mail", "A", "1.2.3.5", 0),
+		},
+	}
+
+	corrections, err := api.GetDomainCorrections(dc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var payloads []record
+	for _, c := range corrections {
+		for _, line := range strings.Split(c.Msg, "\n") {
+			payload := strings.TrimPrefix(strings.TrimSpace(line), "payload: ")
+			if payload == strings.TrimSpace(line) {
+				continue
+			}
+			var r record
+			if err := json.Unmarshal([]byte(payload), &r); err != nil {
+				t.Fatalf("expected a JSON payload; got=%q: %v", payload, err)
+			}
+			payloads = append(payloads, r)
+		}
+	}
+
+	if len(payloads) != 2 {
+		t.Fatalf("expected the payloads of the creation and the modification; got=%v", corrections)
+	}
+	created, modified := payloads[0], payloads[1]
+	if created.Name != "mail" || created.Value != "1.2.3.5" || created.ZoneID != "zone1" || created.TTL != nil {
+		t.Errorf("unexpected payload of the creation: %+v", created)
+	}
+	if modified.ID != "1" || modified.Name != "www" || modified.Value != "5.6.7.8" || modified.TTL == nil || *modified.TTL != 300 {
+		t.Errorf("unexpected payload of the modification: %+v", modified)
+	}
+}
+
+func TestGetDomainCorrections_pendingZone(t *testing.T) {
+	api := &hetznerProvider{
+		zones: map[string]zone{
+			"example.com": {ID: "zone1", Name: "example.com", Status: "pending"},
+		},
+	}
+	dc := &models.DomainConfig{
+		Name:    "example.com",
+		Records: models.Records{makeRC("www", "A", "1.2.3.4", 300)},
+	}
+
+	_, err := api.GetDomainCorrections(dc)
+	if err == nil {
+		t.Fatal("expected an error for a pending zone")
+	}
+	if !strings.Contains(err.Error(), "not ready") || !strings.Contains(err.Error(), "pending") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestGetDomainCorrections_unverifiedZone(t *testing.T) {
+	var requests []string
+	api := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		fmt.Fprint(w, `{"zones":[{"id":"zone1","name":"example.com","status":"pending"}]}`)
+	})
+	dc := &models.DomainConfig{
+		Name: "example.com",
+		Records: models.Records{
+			makeRC("www", "A", "1.2.3.4", 300),
+			makeRC("mail", "A", "1.2.3.5", 300),
+		},
+	}
+
+	_, err := api.GetDomainCorrections(dc)
+	if err == nil || !strings.Contains(err.Error(), "not verified") {
+		t.Fatalf("expected an error for an unverified zone; got=%v", err)
+	}
+	// The zone is looked up, but no records are read or written.
+	if len(requests) != 1 || requests[0] != "GET /zones" {
+		t.Errorf("expected only the zone to be looked up; got=%v", requests)
+	}
+}
+
+func TestGetDomainCorrections_defaultTTL(t *testing.T) {
+	var body string
+	api := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/records/bulk" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+		}
+		data, _ := ioutil.ReadAll(r.Body)
+		body = string(data)
+	})
+	api.defaultTTL = 3600
+	api.zones = map[string]zone{"example.com": {ID: "zone1", Name: "example.com"}}
+	dc := &models.DomainConfig{
+		Name:    "example.com",
+		Records: models.Records{makeRC("www", "A", "1.2.3.4", 0)},
+	}
+
+	corrections, err := api.GetDomainCorrectionsAgainst(dc, models.Records{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(corrections) != 1 {
+		t.Fatalf("expected 1 correction; got=%d", len(corrections))
+	}
+	if err := corrections[0].F(); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(body, `"ttl":3600`) {
+		t.Errorf("expected the default TTL in the payload; got=%s", body)
+	}
+}
+
+func TestRequest_redactsAPIKey(t *testing.T) {
+	api := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {})
+	api.apiKey = "s3cr3t"
+	api.baseURL = "http://s3cr3t.invalid:bad-port"
+
+	err := api.request("/zones", "GET", nil, nil)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if strings.Contains(err.Error(), "s3cr3t") {
+		t.Errorf("API key was not redacted: %q", err)
+	}
+}
+
+func TestGetDomainCorrectionsAgainst_ignored(t *testing.T) {
+	api := &hetznerProvider{}
+	dc := &models.DomainConfig{
+		Name: "example.com",
+		Records: models.Records{
+			makeRC("www", "A", "1.2.3.4", 300),
+			makeRC("legacy", "A", "5.6.7.8", 300),
+		},
+		IgnoredNames: []string{"legacy"},
+	}
+	existing := models.Records{
+		makeExisting("1", "www", "A", "1.2.3.4", 300),
+		makeExisting("2", "legacy", "A", "9.9.9.9", 300),
+	}
+
+	var out bytes.Buffer
+	old := printer.DefaultPrinter
+	printer.DefaultPrinter = &printer.ConsolePrinter{Writer: &out}
+	defer func() { printer.DefaultPrinter = old }()
+
+	// Like with any other provider, the differ refuses the ignored record.
+	_, err := api.GetDomainCorrectionsAgainst(dc, existing)
+	if err == nil || !strings.Contains(err.Error(), "IGNORE_NAMEd record: legacy A") {
+		t.Errorf("expected an error for the ignored record; got=%v", err)
+	}
+	if !strings.Contains(out.String(), `A legacy.example.com matches IGNORE_NAME("legacy")`) {
+		t.Errorf("expected a warning naming the rule; got=%q", out.String())
+	}
+}
+
+func TestVerifyCredentials(t *testing.T) {
+	api := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Auth-API-Token") != "good" {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"message":"Invalid authentication credentials"}`)
+		}
+	})
+
+	api.apiKey = "good"
+	if err := api.VerifyCredentials(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	api.apiKey = "bad"
+	if err := api.VerifyCredentials(); err == nil {
+		t.Errorf("expected an error for bad credentials")
+	}
+}
+
+func TestGetZonesRecords_concurrent(t *testing.T) {
+	const latency = 50 * time.Millisecond
+	api := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/zones":
+			fmt.Fprint(w, `{"zones":[{"id":"z1","name":"a.com"},{"id":"z2","name":"b.com"},{"id":"z3","name":"c.com"},{"id":"z4","name":"d.com"}]}`)
+		case "/records":
+			time.Sleep(latency)
+			id := r.URL.Query().Get("zone_id")
+			fmt.Fprintf(w, `{"records":[{"id":"r-%s","name":"www","type":"TXT","value":"%s","ttl":300,"zone_id":"%s"}]}`, id, id, id)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+		}
+	})
+	domains := []string{"a.com", "b.com", "c.com", "d.com"}
+
+	api.zonesConcurrency = 4
+	start := time.Now()
+	results, err := api.GetZonesRecords(domains)
+	elapsed := time.Since(start)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if elapsed >= time.Duration(len(domains))*latency {
+		t.Errorf("expected concurrent fetches to beat %v; took %v", time.Duration(len(domains))*latency, elapsed)
+	}
+	for i, domain := range domains {
+		if len(results[i]) != 1 || results[i][0].GetLabelFQDN() != "www."+domain {
+			t.Errorf("records for %s are not associated with their zone: %v", domain, results[i])
+		}
+		if want := fmt.Sprintf("z%d", i+1); results[i][0].GetTargetField() != want {
+			t.Errorf("expected the records of zone %s for %s; got=%q", want, domain, results[i][0].GetTargetField())
+		}
+	}
+}
+
+func TestGetZoneRecords_sharedZoneCache(t *testing.T) {
+	var mu sync.Mutex
+	listings := 0
+	api := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/zones":
+			mu.Lock()
+			listings++
+			mu.Unlock()
+			// Give the other callers a chance to ask for the zones, too.
+			time.Sleep(20 * time.Millisecond)
+			fmt.Fprint(w, `{"zones":[{"id":"z1","name":"a.com"},{"id":"z2","name":"b.com"},{"id":"z3","name":"c.com"},{"id":"z4","name":"d.com"}]}`)
+		case "/records":
+			fmt.Fprint(w, `{"records":[]}`)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+		}
+	})
+
+	domains := []string{"a.com", "b.com", "c.com", "d.com", "a.com", "b.com"}
+	errs := make([]error, len(domains))
+	var wg sync.WaitGroup
+	for i, domain := range domains {
+		wg.Add(1)
+		go func(i int, domain string) {
+			defer wg.Done()
+			_, errs[i] = api.GetZoneRecords(domain)
+		}(i, domain)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("%s: %v", domains[i], err)
+		}
+	}
+	if listings != 1 {
+		t.Errorf("expected the zones to be listed once; got=%d", listings)
+	}
+}
+
+func TestGetZoneRecords_sorted(t *testing.T) {
+	api := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"records":[
+			{"id":"1","name":"www","type":"A","value":"5.6.7.8","ttl":300,"zone_id":"zone1"},
+			{"id":"2","name":"@","type":"MX","value":"10 mx.example.com.","ttl":300,"zone_id":"zone1"},
+			{"id":"3","name":"www","type":"A","value":"1.2.3.4","ttl":300,"zone_id":"zone1"},
+			{"id":"4","name":"mail","type":"A","value":"1.2.3.4","ttl":300,"zone_id":"zone1"},
+			{"id":"5","name":"@","type":"A","value":"1.2.3.4","ttl":300,"zone_id":"zone1"}
+		]}`)
+	})
+	api.zones = map[string]zone{"example.com": {ID: "zone1", Name: "example.com"}}
+
+	records, err := api.GetZoneRecords("example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, rc := range records {
+		got = append(got, rc.Original.(*record).ID)
+	}
+	if want := "5,2,4,3,1"; strings.Join(got, ",") != want {
+		t.Errorf("expected records in order %s; got=%s", want, strings.Join(got, ","))
+	}
+}
+
+func TestListZones_deduplicated(t *testing.T) {
+	api := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("page") {
+		case "1":
+			fmt.Fprint(w, `{"zones":[{"id":"z3","name":"c.com"},{"id":"z1","name":"a.com"}],"meta":{"pagination":{"last_page":2}}}`)
+		case "2":
+			fmt.Fprint(w, `{"zones":[{"id":"z1","name":"a.com"},{"id":"z2","name":"b.com"}],"meta":{"pagination":{"last_page":2}}}`)
+		}
+	})
+
+	zones, err := api.ListZones()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "a.com,b.com,c.com"; strings.Join(zones, ",") != want {
+		t.Errorf("expected zones %s; got=%s", want, strings.Join(zones, ","))
+	}
+}
+
+func TestEnsureDomainExists_noCreateZones(t *testing.T) {
+	api := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+		}
+		fmt.Fprint(w, `{"zones":[{"id":"z1","name":"a.com"}]}`)
+	})
+	api.noCreateZones = true
+
+	if err := api.EnsureDomainExists("a.com"); err != nil {
+		t.Errorf("unexpected error for an existing zone: %v", err)
+	}
+	err := api.EnsureDomainExists("b.com")
+	if err == nil || !strings.Contains(err.Error(), "auto-create is disabled") {
+		t.Errorf("expected the zone creation to be refused; got=%v", err)
+	}
+}
+
+func TestEnsureDomainExists_waitsForZone(t *testing.T) {
+	defer func(interval time.Duration) { zoneCreationPollInterval = interval }(zoneCreationPollInterval)
+	zoneCreationPollInterval = 0
+
+	listed := 0
+	api := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "POST /zones":
+			fmt.Fprint(w, `{"zone":{"id":"z2","name":"b.com"}}`)
+		case "GET /zones":
+			listed++
+			// The new zone is only listed on the second request after
+			// its creation.
+			if listed < 3 {
+				fmt.Fprint(w, `{"zones":[{"id":"z1","name":"a.com"}]}`)
+				return
+			}
+			fmt.Fprint(w, `{"zones":[{"id":"z1","name":"a.com"},{"id":"z2","name":"b.com"}]}`)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+		}
+	})
+
+	if err := api.EnsureDomainExists("b.com"); err != nil {
+		t.Fatal(err)
+	}
+	if listed != 3 {
+		t.Errorf("expected the zones to be listed 3 times; got=%d", listed)
+	}
+	z, err := api.getZone("b.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if z.ID != "z2" {
+		t.Errorf("expected the new zone; got=%+v", z)
+	}
+}
+
+func TestGetNameservers_sorted(t *testing.T) {
+	api := &hetznerProvider{
+		zones: map[string]zone{"example.com": {ID: "zone1", Name: "example.com", NameServers: []string{
+			"oxygen.ns.hetzner.com.",
+			"helium.ns.hetzner.de",
+			"hydrogen.ns.hetzner.com.",
+		}}},
+	}
+
+	nameservers, err := api.GetNameservers("example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, ns := range nameservers {
+		got = append(got, ns.Name)
+	}
+	if want := "helium.ns.hetzner.de,hydrogen.ns.hetzner.com,oxygen.ns.hetzner.com"; strings.Join(got, ",") != want {
+		t.Errorf("expected nameservers %s; got=%s", want, strings.Join(got, ","))
+	}
+}
+
+func TestNew_apiKey(t *testing.T) {
+	for _, test := range []struct {
+		key string
+		err string
+	}{
+		{"", "missing"},
+		{"your-api-key", "placeholder"},
+		{" Ab3dEf6hIj9kLm2oPq5sTu8wXy1zAb4d", "whitespace"},
+		{"Ab3dEf6hIj9kLm2oPq5sTu8wXy1zAb4d\n", "whitespace"},
+		{"Bearer Ab3dEf6hIj9kLm2oPq5sTu8wXy1zAb4d", "whitespace"},
+		{`"Ab3dEf6hIj9kLm2oPq5sTu8wXy1zAb4d"`, "quotes"},
+		{"Ab3dEf6hIj9kLm2oPq5sTu8wXy1zAb4d", ""},
+		{"a-future_format.of+tokens/that=is~longer0123456789", ""},
+	} {
+		_, err := New(map[string]string{"api_key": test.key}, nil)
+		if test.err == "" {
+			if err != nil {
+				t.Errorf("%q: unexpected error: %v", test.key, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("%q: expected an error mentioning %q; got=%v", test.key, test.err, err)
+		}
+	}
+}
+
+func TestNew_contradictorySettings(t *testing.T) {
+	for _, test := range []struct {
+		settings map[string]string
+		err      string
+	}{
+		{map[string]string{"prune_only": "true", "read_only": "true"}, "prune_only and read_only"},
+		{map[string]string{"max_changes_override": "true"}, "without max_changes"},
+		{map[string]string{"max_changes_override": "true", "max_changes": "10"}, ""},
+		{map[string]string{"prune_only": "true"}, ""},
+	} {
+		test.settings["api_key"] = "test"
+		_, err := New(test.settings, nil)
+		if test.err == "" {
+			if err != nil {
+				t.Errorf("%v: unexpected error: %v", test.settings, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("%v: expected an error mentioning %q; got=%v", test.settings, test.err, err)
+		}
+	}
+}
+
+func TestGetNameservers_override(t *testing.T) {
+	zones := map[string]zone{"example.com": {ID: "zone1", Name: "example.com", NameServers: []string{"hydrogen.ns.hetzner.com."}}}
+	settings := map[string]string{"api_key": "test", "nameservers": "ns2.example.net., ns1.example.net"}
+	provider, err := New(settings, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	api := provider.(*hetznerProvider)
+	api.zones = zones
+
+	nameservers, err := api.GetNameservers("example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(nameservers) != 2 || nameservers[0].Name != "ns1.example.net" || nameservers[1].Name != "ns2.example.net" {
+		t.Errorf("expected the configured nameservers; got=%v", nameservers)
+	}
+
+	// Without the setting the zone's nameservers are reported.
+	api = &hetznerProvider{zones: zones}
+	nameservers, err = api.GetNameservers("example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(nameservers) != 1 || nameservers[0].Name != "hydrogen.ns.hetzner.com" {
+		t.Errorf("expected the zone's nameservers; got=%v", nameservers)
+	}
+}
+
+func TestGetZoneRecordsNative(t *testing.T) {
+	api := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"records":[
+			{"id":"r2","name":"www","type":"A","value":"1.2.3.4","ttl":300,"zone_id":"zone1","created":"2021-03-31 08:47:40.473 +0000 UTC","modified":"2021-04-01 10:00:00 +0000 UTC"},
+			{"id":"r1","name":"@","type":"NS","value":"hydrogen.ns.hetzner.com.","zone_id":"zone1"}
+		]}`)
+	})
+	api.zones = map[string]zone{"example.com": {ID: "zone1", Name: "example.com"}}
+
+	records, err := api.GetZoneRecordsNative("example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("expected 2 records; got=%+v", records)
+	}
+	for _, r := range records {
+		if r.ID == "" || r.ZoneID != "zone1" {
+			t.Errorf("expected the IDs of the record and zone; got=%+v", r)
+		}
+	}
+	if records[0].Name != "@" || records[0].TTL != 0 || !records[0].Modified.IsZero() {
+		t.Errorf("unexpected apex record: %+v", records[0])
+	}
+	if records[1].TTL != 300 || records[1].Created.Year() != 2021 || records[1].Modified.Month() != time.April {
+		t.Errorf("unexpected www record: %+v", records[1])
+	}
+}
+
+func TestGetDomainCorrectionsAgainst_soa(t *testing.T) {
+	api := &hetznerProvider{}
+	dc := &models.DomainConfig{
+		Name: "example.com",
+		Records: models.Records{
+			makeRC("@", "SOA", "ns1.example.com. hostmaster.example.com. 1 7200 3600 1209600 300", 300),
+			makeRC("www", "A", "1.2.3.4", 300),
+		},
+	}
+	existing := models.Records{
+		makeExisting("1", "www", "A", "1.2.3.4", 300),
+	}
+
+	corrections, err := api.GetDomainCorrectionsAgainst(dc, existing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range corrections {
+		if strings.Contains(c.Msg, "SOA") {
+			t.Errorf("unexpected SOA correction: %q", c.Msg)
+		}
+	}
+	if len(corrections) != 0 {
+		t.Errorf("expected no corrections; got=%d", len(corrections))
+	}
+}
+
+func TestGetZoneRecordsModifiedSince(t *testing.T) {
+	api := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"records":[
+			{"id":"1","name":"old","type":"A","value":"1.2.3.4","ttl":300,"zone_id":"zone1","modified":"2021-03-01 08:00:00.000 +0000 UTC"},
+			{"id":"2","name":"new","type":"A","value":"1.2.3.4","ttl":300,"zone_id":"zone1","modified":"2021-03-31 08:47:40.473 +0000 UTC"},
+			{"id":"3","name":"unknown","type":"A","value":"1.2.3.4","ttl":300,"zone_id":"zone1"}
+		]}`)
+	})
+	api.zones = map[string]zone{"example.com": {ID: "zone1", Name: "example.com"}}
+
+	since := time.Date(2021, 3, 15, 0, 0, 0, 0, time.UTC)
+	records, err := api.GetZoneRecordsModifiedSince("example.com", since)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, rc := range records {
+		got = append(got, rc.GetLabel())
+	}
+	if want := "new,unknown"; strings.Join(got, ",") != want {
+		t.Errorf("expected records %s; got=%s", want, strings.Join(got, ","))
+	}
+}
+
+func TestGetDomainCorrections_zoneTTL(t *testing.T) {
+	api := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" || r.URL.Path != "/records" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+		}
+		fmt.Fprint(w, `{"records":[
+			{"id":"1","name":"www","type":"A","value":"1.2.3.4","ttl":0,"zone_id":"zone1"},
+			{"id":"2","name":"mail","type":"A","value":"5.6.7.8","zone_id":"zone1"}
+		]}`)
+	})
+	api.zones = map[string]zone{"example.com": {ID: "zone1", Name: "example.com", TTL: 3600}}
+	dc := &models.DomainConfig{
+		Name: "example.com",
+		Records: models.Records{
+			makeRC("www", "A", "1.2.3.4", 3600),
+			makeRC("mail", "A", "5.6.7.8", 0),
+		},
+	}
+
+	corrections, err := api.GetDomainCorrections(dc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(corrections) != 0 {
+		t.Errorf("expected no corrections; got=%d", len(corrections))
+		for _, c := range corrections {
+			t.Log(c.Msg)
+		}
+	}
+}
+
+func TestGetDomainCorrectionsAgainst_parallelDeletes(t *testing.T) {
+	api := &hetznerProvider{deleteConcurrency: 4}
+	dc := &models.DomainConfig{Name: "example.com"}
+	existing := models.Records{
+		makeExisting("1", "a", "A", "1.2.3.4", 300),
+		makeExisting("2", "b", "A", "1.2.3.4", 300),
+	}
+
+	corrections, err := api.GetDomainCorrectionsAgainst(dc, existing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(corrections) != 1 || !strings.HasPrefix(corrections[0].Msg, "Batch deletion of records:") {
+		t.Errorf("expected a single batch deletion; got=%d corrections", len(corrections))
+	}
+}
+
+func TestGetDomainCorrectionsAgainst_bulkDeletes(t *testing.T) {
+	var mu sync.Mutex
+	var requests, running, maxRunning int
+	api := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "DELETE" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+		}
+		mu.Lock()
+		requests++
+		running++
+		if running > maxRunning {
+			maxRunning = running
+		}
+		mu.Unlock()
+		time.Sleep(5 * time.Millisecond)
+		mu.Lock()
+		running--
+		mu.Unlock()
+	})
+	dc := &models.DomainConfig{Name: "example.com"}
+
+	// A few deletions are still sent one at a time.
+	var existing models.Records
+	for i := 0; i < bulkDeleteThreshold-1; i++ {
+		existing = append(existing, makeExisting(fmt.Sprint(i), fmt.Sprintf("r%d", i), "A", "1.2.3.4", 300))
+	}
+	corrections, err := api.GetDomainCorrectionsAgainst(dc, existing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(corrections) != len(existing) {
+		t.Errorf("expected one correction per deletion; got=%d", len(corrections))
+	}
+
+	// Tearing down a zone deletes the records in parallel.
+	for i := bulkDeleteThreshold - 1; i < 3*bulkDeleteThreshold; i++ {
+		existing = append(existing, makeExisting(fmt.Sprint(i), fmt.Sprintf("r%d", i), "A", "1.2.3.4", 300))
+	}
+	corrections, err = api.GetDomainCorrectionsAgainst(dc, existing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(corrections) != 1 {
+		t.Fatalf("expected a single batch deletion; got=%d corrections", len(corrections))
+	}
+	if err := corrections[0].F(); err != nil {
+		t.Fatal(err)
+	}
+	if requests != len(existing) {
+		t.Errorf("expected %d requests; got=%d", len(existing), requests)
+	}
+	if maxRunning < 2 || maxRunning > bulkDeleteConcurrency {
+		t.Errorf("expected up to %d parallel requests; got=%d", bulkDeleteConcurrency, maxRunning)
+	}
+
+	// Unless delete_concurrency says otherwise.
+	api.deleteConcurrency = 1
+	corrections, err = api.GetDomainCorrectionsAgainst(dc, existing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(corrections) != len(existing) {
+		t.Errorf("expected one correction per deletion; got=%d", len(corrections))
+	}
+}
+
+func TestGetDomainCorrectionsAgainst_typeChange(t *testing.T) {
+	api := &hetznerProvider{}
+	dc := &models.DomainConfig{
+		Name:    "example.com",
+		Records: models.Records{makeRC("www", "CNAME", "example.net.", 300)},
+	}
+	existing := models.Records{makeExisting("1", "www", "A", "1.2.3.4", 300)}
+
+	corrections, err := api.GetDomainCorrectionsAgainst(dc, existing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(corrections) != 2 {
+		t.Fatalf("expected 2 corrections; got=%d", len(corrections))
+	}
+	if !strings.HasPrefix(corrections[0].Msg, "DELETE A www.example.com") {
+		t.Errorf("expected the A record to be deleted first; got=%q", corrections[0].Msg)
+	}
+	if !strings.Contains(corrections[1].Msg, "CREATE CNAME www.example.com") {
+		t.Errorf("expected the CNAME record to be created; got=%q", corrections[1].Msg)
+	}
+}
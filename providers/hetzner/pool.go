@@ -0,0 +1,80 @@
+package hetzner
+
+import "sync"
+
+// adaptivePool limits how many tasks run at the same time. The limit is
+// halved whenever a task was rate-limited and raised by one again once
+// as many tasks in a row completed without being rate-limited. This way
+// large batches settle at a concurrency that HETZNER's limits allow.
+type adaptivePool struct {
+	mu        sync.Mutex
+	cond      *sync.Cond
+	max       int
+	limit     int
+	running   int
+	successes int
+}
+
+func newAdaptivePool(max int) *adaptivePool {
+	if max < 1 {
+		max = 1
+	}
+	pool := &adaptivePool{max: max, limit: max}
+	pool.cond = sync.NewCond(&pool.mu)
+	return pool
+}
+
+// acquire blocks until another task may start.
+func (pool *adaptivePool) acquire() {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+	for pool.running >= pool.limit {
+		pool.cond.Wait()
+	}
+	pool.running++
+}
+
+// release marks a task as done and adjusts the limit.
+func (pool *adaptivePool) release(rateLimited bool) {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+	pool.running--
+	if rateLimited {
+		pool.limit = (pool.limit + 1) / 2
+		pool.successes = 0
+	} else if pool.limit < pool.max {
+		pool.successes++
+		if pool.successes >= pool.limit {
+			pool.limit++
+			pool.successes = 0
+		}
+	}
+	pool.cond.Broadcast()
+}
+
+// currentLimit returns how many tasks may currently run at the same time.
+func (pool *adaptivePool) currentLimit() int {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+	return pool.limit
+}
+
+// run calls task for 0..n-1 in the pool and waits for all of them.
+// rateLimited returns the number of requests rate-limited so far, a
+// task that sees it change is treated as rate-limited.
+func (pool *adaptivePool) run(n int, rateLimited func() uint64, task func(i int) error) []error {
+	errs := make([]error, n)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		pool.acquire()
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			before := rateLimited()
+			errs[i] = task(i)
+			pool.release(rateLimited() != before)
+		}(i)
+	}
+	wg.Wait()
+	return errs
+}
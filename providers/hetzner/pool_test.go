@@ -0,0 +1,91 @@
+package hetzner
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestAdaptivePool(t *testing.T) {
+	pool := newAdaptivePool(8)
+
+	// A burst of 429s halves the limit each time, but never below 1.
+	var limits []int
+	for i := 0; i < 5; i++ {
+		pool.acquire()
+		pool.release(true)
+		limits = append(limits, pool.currentLimit())
+	}
+	if fmt.Sprint(limits) != "[4 2 1 1 1]" {
+		t.Errorf("expected the pool to scale down; got=%v", limits)
+	}
+
+	// Once the 429s subside, the limit recovers step by step.
+	limits = nil
+	for pool.currentLimit() < 8 && len(limits) < 100 {
+		pool.acquire()
+		pool.release(false)
+		limits = append(limits, pool.currentLimit())
+	}
+	if pool.currentLimit() != 8 {
+		t.Fatalf("expected the pool to recover to 8; got=%d", pool.currentLimit())
+	}
+	if want := 1 + 2 + 3 + 4 + 5 + 6 + 7; len(limits) != want {
+		t.Errorf("expected %d successes to recover; got=%d", want, len(limits))
+	}
+
+	// The limit never exceeds the maximum.
+	pool.acquire()
+	pool.release(false)
+	if pool.currentLimit() != 8 {
+		t.Errorf("expected the limit to stay at 8; got=%d", pool.currentLimit())
+	}
+}
+
+func TestDeleteRecords_backsOff(t *testing.T) {
+	var mu sync.Mutex
+	var running, maxRunning int
+	var requests int32
+	api := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "DELETE" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+		}
+		mu.Lock()
+		running++
+		if running > maxRunning {
+			maxRunning = running
+		}
+		mu.Unlock()
+		defer func() {
+			mu.Lock()
+			running--
+			mu.Unlock()
+		}()
+
+		// Rate-limit the first requests, then let everything through.
+		if atomic.AddInt32(&requests, 1) <= 4 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	})
+	api.deleteConcurrency = 4
+
+	records := make([]record, 40)
+	for i := range records {
+		records[i] = record{ID: fmt.Sprint(i), Type: "A"}
+	}
+	if err := api.deleteRecords(records); err != nil {
+		t.Fatal(err)
+	}
+	if n := atomic.LoadInt32(&requests); n != 44 {
+		t.Errorf("expected 44 requests; got=%d", n)
+	}
+	if api.requestRateLimiter.rateLimitedCount() != 4 {
+		t.Errorf("expected 4 rate-limited requests; got=%d", api.requestRateLimiter.rateLimitedCount())
+	}
+	if maxRunning > 4 {
+		t.Errorf("expected at most 4 parallel requests; got=%d", maxRunning)
+	}
+}
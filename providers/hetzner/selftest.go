@@ -0,0 +1,95 @@
+package hetzner
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v3/models"
+	"github.com/StackExchange/dnscontrol/v3/providers"
+)
+
+// selfTestLabel is the label below which SelfTest creates its records.
+const selfTestLabel = "_dnscontrol-test"
+
+// selfTestBaseTypes are the record types every provider supports.
+var selfTestBaseTypes = []string{"A", "AAAA", "CNAME", "MX", "NS", "TXT"}
+
+// selfTestCapabilities maps capabilities to the record type they enable.
+var selfTestCapabilities = map[providers.Capability]string{
+	providers.CanUseCAA:   "CAA",
+	providers.CanUseDS:    "DS",
+	providers.CanUseHINFO: "HINFO",
+	providers.CanUsePTR:   "PTR",
+	providers.CanUseRP:    "RP",
+	providers.CanUseSRV:   "SRV",
+	providers.CanUseSSHFP: "SSHFP",
+	providers.CanUseTLSA:  "TLSA",
+}
+
+// selfTestValues are the values of the records created by SelfTest.
+var selfTestValues = map[string]string{
+	"A":     "192.0.2.1",
+	"AAAA":  "2001:db8::1",
+	"CAA":   `0 issue "letsencrypt.org"`,
+	"CNAME": "www.example.net.",
+	"DS":    "12345 13 2 " + strings.Repeat("ab", 32),
+	"HINFO": `"dnscontrol" "self-test"`,
+	"MX":    "10 mail.example.net.",
+	"NS":    "ns1.example.net.",
+	"PTR":   "www.example.net.",
+	"RP":    "hostmaster.example.net. .",
+	"SRV":   "10 10 443 www.example.net.",
+	"SSHFP": "1 2 " + strings.Repeat("ab", 32),
+	"TLSA":  "3 1 1 " + strings.Repeat("ab", 32),
+	"TXT":   "dnscontrol self-test",
+}
+
+// selfTestTypes returns the record types HETZNER claims to support.
+func selfTestTypes() []string {
+	types := append([]string{}, selfTestBaseTypes...)
+	for capability, rtype := range selfTestCapabilities {
+		if note := features[capability]; note != nil && note.HasFeature {
+			types = append(types, rtype)
+		}
+	}
+	sort.Strings(types)
+	return types
+}
+
+// SelfTest checks against the live account that HETZNER accepts a record
+// of each type it claims to support. It creates one record per type at
+// <type>._dnscontrol-test in domain and deletes all records below
+// _dnscontrol-test afterwards, including those left by an earlier run.
+// The error is only set if the test could not be run or cleaned up.
+func (api *hetznerProvider) SelfTest(domain string) ([]providers.SelfTestResult, error) {
+	z, err := api.client().getZone(domain)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []providers.SelfTestResult
+	for _, rtype := range selfTestTypes() {
+		rc := &models.RecordConfig{Type: rtype, TTL: 300}
+		rc.SetLabel(strings.ToLower(rtype)+"."+selfTestLabel, domain)
+		if err := rc.PopulateFromString(rtype, selfTestValues[rtype], domain); err != nil {
+			return nil, err
+		}
+		err := api.client().bulkCreateRecords([]record{*fromRecordConfig(rc, z)})
+		results = append(results, providers.SelfTestResult{Type: rtype, Err: err})
+	}
+
+	existing, err := api.client().getAllRecordsInZone(z)
+	if err != nil {
+		return results, err
+	}
+	var leftovers []record
+	for _, r := range existing {
+		if r.Name == selfTestLabel || strings.HasSuffix(r.Name, "."+selfTestLabel) {
+			leftovers = append(leftovers, r)
+		}
+	}
+	if len(leftovers) > 0 {
+		err = api.client().deleteRecords(leftovers)
+	}
+	return results, err
+}
@@ -0,0 +1,54 @@
+package hetzner
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSelfTest(t *testing.T) {
+	ttl := 300
+	client := &fakeClient{
+		zones: map[string]zone{"example.com": {ID: "zone1", Name: "example.com"}},
+		records: map[string][]record{"zone1": {
+			{ID: "1", Name: "www", Type: "A", Value: "1.2.3.4", TTL: &ttl, ZoneID: "zone1"},
+			{ID: "2", Name: "a._dnscontrol-test", Type: "A", Value: "192.0.2.1", TTL: &ttl, ZoneID: "zone1"},
+		}},
+		createErrs: map[string]error{"HINFO": errors.New("422 Unprocessable Entity")},
+	}
+	api := &hetznerProvider{fakeClient: client}
+
+	results, err := api.SelfTest("example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	types := selfTestTypes()
+	if len(results) != len(types) {
+		t.Fatalf("expected a result for each of %v; got=%+v", types, results)
+	}
+	for i, result := range results {
+		if result.Type != types[i] {
+			t.Errorf("%d: expected type %s; got=%s", i, types[i], result.Type)
+		}
+		if failed := result.Err != nil; failed != (result.Type == "HINFO") {
+			t.Errorf("%s: unexpected result: %v", result.Type, result.Err)
+		}
+	}
+	if len(client.created) != len(types)-1 {
+		t.Errorf("expected %d records to be created; got=%+v", len(types)-1, client.created)
+	}
+	for _, r := range client.created {
+		if r.ZoneID != "zone1" || r.Value == "" {
+			t.Errorf("unexpected record: %+v", r)
+		}
+	}
+	// The records created now and the one left by an earlier run.
+	if len(client.deleted) != len(types) {
+		t.Errorf("expected %d records to be deleted; got=%+v", len(types), client.deleted)
+	}
+	for _, r := range client.deleted {
+		if r.ID == "1" {
+			t.Errorf("expected records outside of _dnscontrol-test to be kept; got=%+v", r)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package hetzner
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v3/models"
+	"github.com/miekg/dns"
+)
+
+// The domain metadata keys for the fields of the SOA record. The serial
+// is always maintained by HETZNER.
+const (
+	metaSOARefresh = "hetzner_soa_refresh"
+	metaSOARetry   = "hetzner_soa_retry"
+	metaSOAExpire  = "hetzner_soa_expire"
+	metaSOAMinTTL  = "hetzner_soa_minttl"
+)
+
+// soaFields are the SOA fields that can be set with domain metadata.
+var soaFields = []struct {
+	meta  string
+	name  string
+	field func(soa *dns.SOA) *uint32
+}{
+	{metaSOARefresh, "refresh", func(soa *dns.SOA) *uint32 { return &soa.Refresh }},
+	{metaSOARetry, "retry", func(soa *dns.SOA) *uint32 { return &soa.Retry }},
+	{metaSOAExpire, "expire", func(soa *dns.SOA) *uint32 { return &soa.Expire }},
+	{metaSOAMinTTL, "minttl", func(soa *dns.SOA) *uint32 { return &soa.Minttl }},
+}
+
+// soaCorrection returns a correction that changes the SOA record of z to
+// what the domain metadata asks for, or nil if it already matches or no
+// field is set. The records API refuses to touch the SOA record, so the
+// zone is exported and imported again with the changed SOA record. The
+// correction must come after the record corrections of the zone, which
+// are described by summary.
+func (api *hetznerProvider) soaCorrection(dc *models.DomainConfig, z *zone, summary *diffSummary) (*models.Correction, error) {
+	desired := map[string]uint32{}
+	for _, f := range soaFields {
+		value := dc.Metadata[f.meta]
+		if value == "" {
+			continue
+		}
+		n, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected value for %s: %w", f.meta, err)
+		}
+		desired[f.meta] = uint32(n)
+	}
+	if len(desired) == 0 {
+		return nil, nil
+	}
+
+	zoneText, err := api.client().exportZoneFile(z.ID)
+	if err != nil {
+		return nil, err
+	}
+	_, changes, count, err := editSOA(zoneText, z.Name, desired)
+	if err != nil {
+		return nil, err
+	}
+	if len(changes) == 0 {
+		return nil, nil
+	}
+	// The number of records once the other corrections are applied.
+	creates, _, deletes := summary.counts()
+	expected := count + creates - deletes
+
+	return &models.Correction{
+		Msg: fmt.Sprintf("Change the SOA record of zone %s: %s (imports the whole zone)", z.Name, strings.Join(changes, ", ")),
+		F: func() error {
+			// The import replaces the whole zone. Export it again, so that
+			// the corrections applied before this one are kept.
+			zoneText, err := api.client().exportZoneFile(z.ID)
+			if err != nil {
+				return err
+			}
+			newZoneText, _, count, err := editSOA(zoneText, z.Name, desired)
+			if err != nil {
+				return err
+			}
+			// Someone else changed the zone, or a correction failed. The
+			// import would silently undo or redo those changes.
+			if count != expected {
+				return fmt.Errorf("zone %s has %d records instead of the expected %d, not changing its SOA record", z.Name, count, expected)
+			}
+			return api.client().importZoneFile(z.ID, newZoneText)
+		},
+	}, nil
+}
+
+// editSOA returns zoneText with the fields of its SOA record set to the
+// desired values, keyed by metadata key, a description of each change and
+// the number of records in the zone. The other records are left as they
+// are.
+func editSOA(zoneText, origin string, desired map[string]uint32) (string, []string, int, error) {
+	rrs, err := parseZoneFile(zoneText, origin)
+	if err != nil {
+		return "", nil, 0, err
+	}
+	var soa *dns.SOA
+	for _, rr := range rrs {
+		if s, ok := rr.(*dns.SOA); ok {
+			soa = s
+		}
+	}
+	if soa == nil {
+		return "", nil, 0, fmt.Errorf("zone %s has no SOA record", origin)
+	}
+
+	var changes []string
+	for _, f := range soaFields {
+		n, ok := desired[f.meta]
+		if !ok || *f.field(soa) == n {
+			continue
+		}
+		changes = append(changes, fmt.Sprintf("%s %d -> %d", f.name, *f.field(soa), n))
+		*f.field(soa) = n
+	}
+
+	lines := make([]string, len(rrs))
+	for i, rr := range rrs {
+		lines[i] = rr.String()
+	}
+	return strings.Join(lines, "\n") + "\n", changes, len(rrs), nil
+}
+
+// parseZoneFile parses a BIND zone file as exported by HETZNER.
+func parseZoneFile(zoneText, origin string) ([]dns.RR, error) {
+	var rrs []dns.RR
+	zp := dns.NewZoneParser(strings.NewReader(zoneText), dns.Fqdn(origin), "")
+	for rr, ok := zp.Next(); ok; rr, ok = zp.Next() {
+		rrs = append(rrs, rr)
+	}
+	if err := zp.Err(); err != nil {
+		return nil, fmt.Errorf("failed parsing the zone file of %s: %w", origin, err)
+	}
+	// Importing what was parsed would delete any record that was skipped.
+	if n := countRecordLines(zoneText); len(rrs) < n {
+		return nil, fmt.Errorf("failed parsing the zone file of %s: %d records found in %d lines", origin, len(rrs), n)
+	}
+	return rrs, nil
+}
+
+// countRecordLines returns the number of records in a zone file, assuming
+// one record per line: blank lines, comments and directives are skipped,
+// as are the lines continuing a record in parentheses.
+func countRecordLines(zoneText string) int {
+	n, depth := 0, 0
+	for _, line := range strings.Split(zoneText, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if depth == 0 && trimmed != "" && !strings.HasPrefix(trimmed, ";") && !strings.HasPrefix(trimmed, "$") {
+			n++
+		}
+		inQuotes := false
+		for i := 0; i < len(line); i++ {
+			switch c := line[i]; {
+			case c == '\\' && inQuotes:
+				i++
+			case c == '"':
+				inQuotes = !inQuotes
+			case c == ';' && !inQuotes:
+				i = len(line)
+			case c == '(' && !inQuotes:
+				depth++
+			case c == ')' && !inQuotes && depth > 0:
+				depth--
+			}
+		}
+	}
+	return n
+}
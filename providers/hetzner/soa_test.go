@@ -0,0 +1,19 @@
+package hetzner
+
+import "testing"
+
+func TestCountRecordLines(t *testing.T) {
+	for _, test := range []struct {
+		zoneText string
+		expected int
+	}{
+		{"", 0},
+		{"$ORIGIN example.com.\n$TTL 86400\n\n; comment\nwww\t300\tIN\tA\t1.2.3.4\n", 1},
+		{"@\tIN\tSOA\tns. dns. (\n\t1 ; serial\n\t86400 10800 3600000 3600 )\nwww\tIN\tA\t1.2.3.4\n", 2},
+		{"txt\tIN\tTXT\t\"(not a paren; nor a comment\"\nwww\tIN\tA\t1.2.3.4\n", 2},
+	} {
+		if n := countRecordLines(test.zoneText); n != test.expected {
+			t.Errorf("expected %d records in %q; got=%d", test.expected, test.zoneText, n)
+		}
+	}
+}
@@ -1,7 +1,15 @@
 package hetzner
 
 import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
 	"github.com/StackExchange/dnscontrol/v3/models"
+	"github.com/StackExchange/dnscontrol/v3/pkg/txtutil"
+	"github.com/miekg/dns"
+	"github.com/miekg/dns/dnsutil"
 )
 
 type bulkCreateRecordsRequest struct {
@@ -14,83 +22,295 @@ type bulkUpdateRecordsRequest struct {
 
 type createRecordRequest struct {
 	Name   string `json:"name"`
-	TTL    int    `json:"ttl"`
+	TTL    *int   `json:"ttl,omitempty"`
 	Type   string `json:"type"`
 	Value  string `json:"value"`
 	ZoneID string `json:"zone_id"`
 }
 
 type createZoneRequest struct {
+	Name           string `json:"name"`
+	IsSecondaryDNS bool   `json:"is_secondary_dns,omitempty"`
+}
+
+type updateZoneRequest struct {
 	Name string `json:"name"`
+	TTL  int    `json:"ttl"`
+}
+
+type errorResponse struct {
+	Message string `json:"message"`
+	Error   struct {
+		Message string `json:"message"`
+		Code    int    `json:"code"`
+	} `json:"error"`
 }
 
 type getAllRecordsResponse struct {
 	Records []record `json:"records"`
 	Meta    struct {
-		Pagination struct {
-			LastPage int `json:"last_page"`
-		} `json:"pagination"`
+		Pagination pagination `json:"pagination"`
 	} `json:"meta"`
 }
 
 type getAllZonesResponse struct {
 	Zones []zone `json:"zones"`
 	Meta  struct {
-		Pagination struct {
-			LastPage int `json:"last_page"`
-		} `json:"pagination"`
+		Pagination pagination `json:"pagination"`
 	} `json:"meta"`
 }
 
+type getZoneResponse struct {
+	Zone zone `json:"zone"`
+}
+
+type getAllPrimaryServersResponse struct {
+	PrimaryServers []primaryServer `json:"primary_servers"`
+}
+
+type pagination struct {
+	Page         int `json:"page"`
+	PerPage      int `json:"per_page"`
+	LastPage     int `json:"last_page"`
+	TotalEntries int `json:"total_entries"`
+}
+
+type primaryServer struct {
+	ID      string `json:"id,omitempty"`
+	Address string `json:"address"`
+	Port    int    `json:"port"`
+	ZoneID  string `json:"zone_id"`
+}
+
 type record struct {
-	ID     string `json:"id"`
-	Name   string `json:"name"`
-	TTL    *int   `json:"ttl"`
-	Type   string `json:"type"`
-	Value  string `json:"value"`
-	ZoneID string `json:"zone_id"`
+	ID       string `json:"id"`
+	Created  string `json:"created,omitempty"`
+	Modified string `json:"modified,omitempty"`
+	Name     string `json:"name"`
+	TTL      *int   `json:"ttl,omitempty"` // nil means the default TTL of the zone.
+	Type     string `json:"type"`
+	Value    string `json:"value"`
+	ZoneID   string `json:"zone_id"`
 }
 
 type zone struct {
-	ID          string   `json:"id"`
-	Name        string   `json:"name"`
-	NameServers []string `json:"ns"`
-	TTL         int      `json:"ttl"`
+	ID             string   `json:"id"`
+	Name           string   `json:"name"`
+	IsSecondaryDNS bool     `json:"is_secondary_dns"`
+	NameServers    []string `json:"ns"`
+	Status         string   `json:"status"`
+	TTL            int      `json:"ttl"`
+}
+
+// PrimaryServer is a server a secondary zone is transferred from.
+type PrimaryServer struct {
+	Address string
+	Port    int
+}
+
+// diffSummary describes the record changes of the corrections for a
+// domain.
+type diffSummary struct {
+	creates []*models.RecordConfig // As desired.
+	updates []*models.RecordConfig // As desired.
+	deletes []*models.RecordConfig // As they exist.
+}
+
+// counts returns the number of records to create, update and delete.
+func (s *diffSummary) counts() (creates, updates, deletes int) {
+	return len(s.creates), len(s.updates), len(s.deletes)
+}
+
+// Record describes a record as stored by HETZNER.
+type Record struct {
+	ID       string
+	ZoneID   string
+	Name     string // Relative to the zone, "@" for the apex.
+	Type     string
+	Value    string
+	TTL      int       // 0 if the record uses the default TTL of the zone.
+	Created  time.Time // Zero if HETZNER did not report it.
+	Modified time.Time // Zero if HETZNER did not report it.
+}
+
+func (r *record) toRecord() Record {
+	created, _ := parseTimestamp(r.Created)
+	modified, _ := r.modifiedAt()
+	ttl := 0
+	if r.TTL != nil {
+		ttl = *r.TTL
+	}
+	return Record{
+		ID:       r.ID,
+		ZoneID:   r.ZoneID,
+		Name:     r.Name,
+		Type:     r.Type,
+		Value:    r.Value,
+		TTL:      ttl,
+		Created:  created,
+		Modified: modified,
+	}
 }
 
 func fromRecordConfig(in *models.RecordConfig, zone *zone) *record {
-	ttl := int(in.TTL)
 	record := &record{
-		Name:   in.GetLabel(),
+		Name:   recordName(in, zone),
 		Type:   in.Type,
-		Value:  in.GetTargetField(),
-		TTL:    &ttl,
 		ZoneID: zone.ID,
 	}
+	if in.TTL != 0 {
+		// Otherwise HETZNER applies the default TTL of the zone.
+		ttl := int(in.TTL)
+		record.TTL = &ttl
+	}
 
 	switch record.Type {
-	case "TXT":
+	case "CAA":
+		record.Value = caaToNative(in)
+	default:
 		// Cannot use `in.GetTargetCombined()` for TXTs:
 		// Their validation would complain about a missing `;`.
 		// Test case: single_TXT:Create_a_255-byte_TXT
 		// {"error":{"message":"422 Unprocessable Entity: missing: ; ","code":422}}
-		record.Value = in.GetTargetField()
-	default:
-		record.Value = in.GetTargetCombined()
+		// txtToNative only quotes them where needed.
+		record.Value = in.GetTargetCombinedFunc(txtToNative)
 	}
 
 	return record
 }
 
+// recordName returns the name of in relative to zone, "@" for the apex.
+// HETZNER appends the zone to any name it is sent, including one that is
+// already fully qualified.
+func recordName(in *models.RecordConfig, zone *zone) string {
+	if zone.Name == "" || in.GetLabelFQDN() == "" {
+		return in.GetLabel()
+	}
+	return dnsutil.TrimDomainName(in.GetLabelFQDN(), zone.Name)
+}
+
 func toRecordConfig(domain string, record *record) *models.RecordConfig {
 	rc := &models.RecordConfig{
 		Type:     record.Type,
-		TTL:      uint32(*record.TTL),
 		Original: record,
 	}
+	if record.TTL != nil {
+		// Otherwise the record uses the default TTL of the zone, which
+		// getAllRecordsInZone fills in.
+		rc.TTL = uint32(*record.TTL)
+	}
 	rc.SetLabel(record.Name, domain)
 
-	_ = rc.PopulateFromString(record.Type, record.Value, domain)
+	if rc.Type == "CAA" {
+		_ = setTargetCAA(rc, record.Value)
+	} else {
+		_ = rc.PopulateFromStringFunc(record.Type, record.Value, domain, txtutil.ParseQuoted)
+	}
+
+	if rc.Type == "TLSA" {
+		rc.SetTarget(strings.ToLower(rc.GetTargetField()))
+	}
+
+	switch rc.Type {
+	case "CNAME", "MX", "NS":
+		rc.SetTarget(fqdnTarget(rc.GetTargetField(), domain))
+	case "SRV":
+		// HETZNER may return the target in another case.
+		rc.SetTarget(strings.ToLower(fqdnTarget(rc.GetTargetField(), domain)))
+	}
+
+	if rc.Type == "RP" {
+		// HETZNER may return the names relative to the zone.
+		origin := dns.Fqdn(domain)
+		_ = rc.SetTargetRP(dnsutil.AddOrigin(rc.GetTargetField(), origin), dnsutil.AddOrigin(rc.RpTxt, origin))
+	}
 
 	return rc
 }
+
+// fqdnTarget returns target, a hostname read from HETZNER, as a FQDN.
+// HETZNER may leave out the trailing dot of names within the zone, other
+// names without it are relative to the zone.
+func fqdnTarget(target, domain string) string {
+	if dns.IsFqdn(target) {
+		return target
+	}
+	lower := strings.ToLower(target)
+	if lower == strings.ToLower(domain) || strings.HasSuffix(lower, "."+strings.ToLower(domain)) {
+		return target + "."
+	}
+	return dnsutil.AddOrigin(target, dns.Fqdn(domain))
+}
+
+// caaToNative returns the value of a CAA record as HETZNER expects it,
+// with the value always quoted.
+func caaToNative(in *models.RecordConfig) string {
+	value := strings.ReplaceAll(in.GetTargetField(), `"`, `\"`)
+	return fmt.Sprintf(`%d %s "%s"`, in.CaaFlag, in.CaaTag, value)
+}
+
+// setTargetCAA sets the fields of a CAA record read from HETZNER, which
+// may return the value quoted or unquoted. The value may contain spaces,
+// e.g. the parameters of an issuer, so all that follows the tag is used.
+func setTargetCAA(rc *models.RecordConfig, s string) error {
+	fields := strings.Fields(s)
+	if len(fields) < 3 {
+		return fmt.Errorf("CAA value does not contain 3 fields: (%#v)", s)
+	}
+	flag, tag := fields[0], fields[1]
+	rest := strings.TrimSpace(s[strings.Index(s, tag)+len(tag):])
+	value := strings.ReplaceAll(models.StripQuotes(rest), `\"`, `"`)
+	return rc.SetTargetCAAStrings(flag, tag, value)
+}
+
+// txtToNative returns the strings of a TXT record as HETZNER expects them.
+// A single string of up to 255 bytes is sent as-is, unless it starts with
+// a quote. Anything else is sent as a list of quoted strings of at most 255
+// bytes each, which txtutil.ParseQuoted reads back as the same strings.
+func txtToNative(chunks []string) string {
+	for _, chunk := range chunks {
+		if len(chunk) > 255 {
+			chunks = splitTxt(strings.Join(chunks, ""), 255)
+			break
+		}
+	}
+	if len(chunks) == 1 && !strings.HasPrefix(strings.TrimSpace(chunks[0]), `"`) {
+		return chunks[0]
+	}
+	return txtutil.EncodeQuoted(chunks)
+}
+
+// splitTxt splits s into chunks of at most size bytes, without cutting
+// a multi-byte character in half.
+func splitTxt(s string, size int) []string {
+	var chunks []string
+	for len(s) > size {
+		n := size
+		for n > 0 && !utf8.RuneStart(s[n]) {
+			n--
+		}
+		if n == 0 {
+			n = size
+		}
+		chunks = append(chunks, s[:n])
+		s = s[n:]
+	}
+	return append(chunks, s)
+}
+
+// timestampLayout is the format of the created and modified timestamps
+// returned by HETZNER, e.g. "2021-03-31 08:47:40.473 +0000 UTC".
+const timestampLayout = "2006-01-02 15:04:05.999999999 -0700 MST"
+
+// modifiedAt returns the time the record was last modified.
+func (r *record) modifiedAt() (time.Time, error) {
+	return parseTimestamp(r.Modified)
+}
+
+func parseTimestamp(value string) (time.Time, error) {
+	t, err := time.Parse(timestampLayout, value)
+	if err != nil {
+		return time.Parse(time.RFC3339, value)
+	}
+	return t, nil
+}
@@ -0,0 +1,273 @@
+package hetzner
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/StackExchange/dnscontrol/v3/models"
+)
+
+func TestTxtRoundTrip(t *testing.T) {
+	dkim := "v=DKIM1; k=rsa; p=" + strings.Repeat("MIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8A", 16)[:494]
+	if len(dkim) != 512 {
+		t.Fatalf("bad test data: len=%d", len(dkim))
+	}
+	z := &zone{ID: "zone1", Name: "example.com"}
+
+	for _, autosplit := range []bool{false, true} {
+		rc := &models.RecordConfig{Type: "TXT", TTL: 300}
+		rc.SetLabel("dkim._domainkey", "example.com")
+		rc.SetTargetTXT(dkim)
+		if autosplit {
+			rc.TxtNormalize("multistring")
+		}
+
+		native := fromRecordConfig(rc, z)
+		if strings.Count(native.Value, `" "`) != 2 {
+			t.Errorf("autosplit=%v: expected 3 chunks; got=%q", autosplit, native.Value)
+		}
+
+		back := toRecordConfig("example.com", native)
+		if got := back.GetTargetField(); got != dkim {
+			t.Errorf("autosplit=%v: value changed in round-trip; got=%q", autosplit, got)
+		}
+		if len(back.TxtStrings) != 3 || len(back.TxtStrings[0]) != 255 {
+			t.Errorf("autosplit=%v: unexpected strings: %q", autosplit, back.TxtStrings)
+		}
+	}
+}
+
+func TestSplitTxt(t *testing.T) {
+	// "é" takes 2 bytes, one of them would end up at byte 255.
+	s := "aa" + strings.Repeat("é", 200)
+	chunks := splitTxt(s, 255)
+	if strings.Join(chunks, "") != s {
+		t.Fatalf("expected the chunks to add up to the string; got=%q", chunks)
+	}
+	for _, chunk := range chunks {
+		if len(chunk) > 255 || !utf8.ValidString(chunk) {
+			t.Errorf("expected valid chunks of at most 255 bytes; got %d bytes: %q", len(chunk), chunk)
+		}
+	}
+	if len(chunks[0]) != 254 {
+		t.Errorf("expected the first chunk to end before the cut character; got=%d bytes", len(chunks[0]))
+	}
+}
+
+func TestSupportedTypesRoundTrip(t *testing.T) {
+	z := &zone{ID: "zone1", Name: "example.com"}
+	for _, rtype := range selfTestTypes() {
+		rc := &models.RecordConfig{Type: rtype, TTL: 300}
+		rc.SetLabel("test", "example.com")
+		if err := rc.PopulateFromString(rtype, selfTestValues[rtype], "example.com"); err != nil {
+			t.Errorf("%s: %v", rtype, err)
+			continue
+		}
+		combined := rc.GetTargetCombined()
+
+		back := toRecordConfig("example.com", fromRecordConfig(rc, z))
+		if back.Type != rtype {
+			t.Errorf("%s: type changed in round-trip; got=%s", rtype, back.Type)
+		}
+		if got := back.GetTargetCombined(); got != combined {
+			t.Errorf("%s: value changed in round-trip; want=%q got=%q", rtype, combined, got)
+		}
+	}
+}
+
+func TestSRVRoundTrip(t *testing.T) {
+	z := &zone{ID: "zone1", Name: "example.com"}
+	config := &models.RecordConfig{Type: "SRV", TTL: 300}
+	config.SetLabel("_sip._tcp", "example.com")
+	if err := config.SetTargetSRV(10, 60, 5060, "sip.example.com."); err != nil {
+		t.Fatal(err)
+	}
+
+	native := fromRecordConfig(config, z)
+	if native.Value != "10 60 5060 sip.example.com." {
+		t.Errorf("unexpected value sent to HETZNER: %q", native.Value)
+	}
+	for _, value := range []string{native.Value, "10 60 5060 sip.example.com", "10 60 5060 SIP.example.com."} {
+		native.Value = value
+		back := toRecordConfig("example.com", native)
+		if got, want := back.GetTargetCombined(), config.GetTargetCombined(); got != want {
+			t.Errorf("%q: expected %q; got=%q", value, want, got)
+		}
+	}
+}
+
+func TestTxtQuotesRoundTrip(t *testing.T) {
+	z := &zone{ID: "zone1", Name: "example.com"}
+	for _, txts := range [][]string{
+		{`say "hi" to  everyone`},
+		{`"quoted" at the start`},
+		{`first "part" `, ` second\part`},
+	} {
+		rc := &models.RecordConfig{Type: "TXT", TTL: 300}
+		rc.SetLabel("@", "example.com")
+		rc.SetTargetTXTs(txts)
+
+		native := fromRecordConfig(rc, z)
+		back := toRecordConfig("example.com", native)
+		if strings.Join(back.TxtStrings, "|") != strings.Join(txts, "|") {
+			t.Errorf("strings changed in round-trip via %q; got=%q, want=%q", native.Value, back.TxtStrings, txts)
+		}
+	}
+}
+
+func TestTxtShort(t *testing.T) {
+	rc := &models.RecordConfig{Type: "TXT", TTL: 300}
+	rc.SetLabel("@", "example.com")
+	rc.SetTargetTXT("v=spf1 -all")
+
+	native := fromRecordConfig(rc, &zone{ID: "zone1"})
+	if native.Value != "v=spf1 -all" {
+		t.Errorf("short TXT should be sent unquoted; got=%q", native.Value)
+	}
+}
+
+func TestDSRoundTrip(t *testing.T) {
+	rc := &models.RecordConfig{Type: "DS", TTL: 300}
+	rc.SetLabel("child", "example.com")
+	if err := rc.SetTargetDSString("2371 13 2 1f987cc6583e92df0890718c42d7a9ae77ebc5c5ab0a2f09f8a6efd9d8c7b0d1"); err != nil {
+		t.Fatal(err)
+	}
+
+	native := fromRecordConfig(rc, &zone{ID: "zone1"})
+	if native.Name != "child" {
+		t.Errorf("unexpected name; got=%q", native.Name)
+	}
+
+	back := toRecordConfig("example.com", native)
+	if back.GetLabel() != "child" || back.Type != "DS" {
+		t.Errorf("unexpected record; got=%s %s", back.Type, back.GetLabel())
+	}
+	if back.DsKeyTag != 2371 || back.DsAlgorithm != 13 || back.DsDigestType != 2 {
+		t.Errorf("fields changed in round-trip; got=%d %d %d", back.DsKeyTag, back.DsAlgorithm, back.DsDigestType)
+	}
+	if !strings.EqualFold(back.DsDigest, rc.DsDigest) {
+		t.Errorf("digest changed in round-trip; got=%q", back.DsDigest)
+	}
+	if back.GetTargetCombined() != rc.GetTargetCombined() {
+		t.Errorf("value changed in round-trip; got=%q, want=%q", back.GetTargetCombined(), rc.GetTargetCombined())
+	}
+}
+
+func TestHINFORoundTrip(t *testing.T) {
+	for _, value := range []string{`"Intel" "Linux"`, `"Intel Xeon" "Debian Linux"`} {
+		native := &record{Name: "server", Type: "HINFO", Value: value, TTL: new(int)}
+		rc := toRecordConfig("example.com", native)
+
+		back := fromRecordConfig(rc, &zone{ID: "zone1"})
+		if back.Value != value {
+			t.Errorf("value changed in round-trip; got=%q, want=%q", back.Value, value)
+		}
+	}
+
+	rc := &models.RecordConfig{Type: "HINFO", TTL: 300}
+	rc.SetLabel("server", "example.com")
+	rc.SetTargetHINFO("Intel Xeon", "Linux")
+	native := fromRecordConfig(rc, &zone{ID: "zone1"})
+	if native.Value != `"Intel Xeon" "Linux"` {
+		t.Errorf("HINFO should be sent quoted; got=%q", native.Value)
+	}
+	back := toRecordConfig("example.com", native)
+	if back.GetTargetField() != "Intel Xeon" || back.HinfoOS != "Linux" {
+		t.Errorf("fields changed in round-trip; got cpu=%q os=%q", back.GetTargetField(), back.HinfoOS)
+	}
+}
+
+func TestRPRoundTrip(t *testing.T) {
+	for _, value := range []string{"admin.example.com. info.example.com.", "admin info"} {
+		native := &record{Name: "@", Type: "RP", Value: value, TTL: new(int)}
+		rc := toRecordConfig("example.com", native)
+		if rc.GetTargetField() != "admin.example.com." || rc.RpTxt != "info.example.com." {
+			t.Errorf("names should be fully qualified; got mbox=%q txt=%q", rc.GetTargetField(), rc.RpTxt)
+		}
+
+		back := fromRecordConfig(rc, &zone{ID: "zone1"})
+		if back.Value != "admin.example.com. info.example.com." {
+			t.Errorf("value changed in round-trip; got=%q", back.Value)
+		}
+	}
+}
+
+func TestCAARoundTrip(t *testing.T) {
+	const value = `0 issue "letsencrypt.org"`
+	for _, native := range []string{value, `0 issue letsencrypt.org`} {
+		rc := toRecordConfig("example.com", &record{Name: "@", Type: "CAA", Value: native, TTL: new(int)})
+		if rc.CaaFlag != 0 || rc.CaaTag != "issue" || rc.GetTargetField() != "letsencrypt.org" {
+			t.Errorf("%s: fields not parsed; got=%d %s %q", native, rc.CaaFlag, rc.CaaTag, rc.GetTargetField())
+		}
+
+		back := fromRecordConfig(rc, &zone{ID: "zone1"})
+		if back.Value != value {
+			t.Errorf("%s: value changed in round-trip; got=%q, want=%q", native, back.Value, value)
+		}
+	}
+
+	// Issuer parameters contain spaces.
+	const params = `0 issue "ca.example.net; account=12345 policy=ev"`
+	rc := toRecordConfig("example.com", &record{Name: "@", Type: "CAA", Value: params, TTL: new(int)})
+	if back := fromRecordConfig(rc, &zone{ID: "zone1"}); back.Value != params {
+		t.Errorf("value changed in round-trip; got=%q, want=%q", back.Value, params)
+	}
+}
+
+func TestGetDomainCorrectionsAgainst_quotedCAA(t *testing.T) {
+	api := &hetznerProvider{}
+	dc := &models.DomainConfig{
+		Name:    "example.com",
+		Records: models.Records{makeRC("@", "CAA", `0 issue "letsencrypt.org"`, 300)},
+	}
+	dc.Records[0].SetTarget(`"letsencrypt.org"`)
+	existing := models.Records{makeExisting("1", "@", "CAA", "0 issue letsencrypt.org", 300)}
+
+	corrections, err := api.GetDomainCorrectionsAgainst(dc, existing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(corrections) != 0 {
+		t.Errorf("expected no corrections for differently quoted CAA values; got=%d", len(corrections))
+	}
+}
+
+func TestRecordName(t *testing.T) {
+	z := &zone{ID: "zone1", Name: "example.com"}
+	for _, test := range []struct {
+		label, want string
+	}{
+		{"@", "@"},
+		{"www", "www"},
+		{"a.b", "a.b"},
+	} {
+		rc := makeRC(test.label, "A", "1.2.3.4", 300)
+		if got := fromRecordConfig(rc, z).Name; got != test.want {
+			t.Errorf("%s: expected name %q; got=%q", test.label, test.want, got)
+		}
+
+		// The name is derived from the FQDN, not a mangled label.
+		rc.Name = rc.NameFQDN + "."
+		if got := fromRecordConfig(rc, z).Name; got != test.want {
+			t.Errorf("%s: expected name %q for a fully qualified label; got=%q", test.label, test.want, got)
+		}
+	}
+}
+
+func TestTLSARoundTrip(t *testing.T) {
+	const value = "3 1 1 0b9fa5a59eed715c26c1020c711b4f6ec42d58b0015e14337a39dad301c5afc3"
+	native := &record{Name: "_443._tcp", Type: "TLSA", Value: strings.ToUpper(value), TTL: new(int)}
+	rc := toRecordConfig("example.com", native)
+	if rc.TlsaUsage != 3 || rc.TlsaSelector != 1 || rc.TlsaMatchingType != 1 {
+		t.Errorf("fields not parsed; got=%d %d %d", rc.TlsaUsage, rc.TlsaSelector, rc.TlsaMatchingType)
+	}
+	if err := checkTLSA(rc); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	back := fromRecordConfig(rc, &zone{ID: "zone1"})
+	if back.Value != value {
+		t.Errorf("value changed in round-trip; got=%q, want=%q", back.Value, value)
+	}
+}
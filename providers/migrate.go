@@ -0,0 +1,79 @@
+package providers
+
+import (
+	"github.com/StackExchange/dnscontrol/v3/models"
+	"github.com/StackExchange/dnscontrol/v3/pkg/printer"
+)
+
+// recordTypeCapabilities are the capabilities a provider needs to manage
+// records of a type. Types that are not listed can be used with any
+// provider.
+var recordTypeCapabilities = map[string]Capability{
+	"ALIAS":       CanUseAlias,
+	"AZURE_ALIAS": CanUseAzureAlias,
+	"CAA":         CanUseCAA,
+	"DHCID":       CanUseDHCID,
+	"DS":          CanUseDSForChildren,
+	"HINFO":       CanUseHINFO,
+	"HTTPS":       CanUseHTTPS,
+	"LOC":         CanUseLOC,
+	"NAPTR":       CanUseNAPTR,
+	"PTR":         CanUsePTR,
+	"R53_ALIAS":   CanUseRoute53Alias,
+	"RP":          CanUseRP,
+	"SRV":         CanUseSRV,
+	"SSHFP":       CanUseSSHFP,
+	"SVCB":        CanUseSVCB,
+	"TLSA":        CanUseTLSA,
+}
+
+// canManage returns true if a provider of type pType can manage rc.
+func canManage(pType string, rc *models.RecordConfig) bool {
+	c, ok := recordTypeCapabilities[rc.Type]
+	if !ok {
+		return true
+	}
+	if rc.Type == "DS" && ProviderHasCapability(pType, CanUseDS) {
+		return true
+	}
+	return ProviderHasCapability(pType, c)
+}
+
+// Migrate reads the records of domain from source and returns a
+// DomainConfig with those that a provider of type targetType can manage,
+// e.g. to move the zone to that provider. The records are taken from
+// ExportZoneRecords if source implements ZoneExporter. Records the target
+// cannot manage are left out with a warning, except that an ALIAS below
+// the apex becomes a CNAME.
+func Migrate(source DNSServiceProvider, domain string, targetType string) (*models.DomainConfig, error) {
+	var records models.Records
+	var err error
+	if exporter, ok := source.(ZoneExporter); ok {
+		records, err = exporter.ExportZoneRecords(domain)
+	} else {
+		records, err = source.GetZoneRecords(domain)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	dc := &models.DomainConfig{Name: domain}
+	for _, r := range records {
+		rc := *r
+		rc.Original = nil // Specific to the source.
+		if rc.Type == "ALIAS" && rc.GetLabel() != "@" && !ProviderHasCapability(targetType, CanUseAlias) {
+			printer.Warnf("%s does not support ALIAS records. Migrating %s as a CNAME\n", targetType, rc.GetLabelFQDN())
+			rc.Type = "CNAME"
+		}
+		if !canManage(targetType, &rc) {
+			printer.Warnf("%s does not support %s records. Skipping %s %s\n", targetType, rc.Type, rc.GetLabelFQDN(), rc.GetTargetCombined())
+			continue
+		}
+		if rc.HasFormatIdenticalToTXT() && len(rc.TxtStrings) > 1 && !ProviderHasCapability(targetType, CanUseTXTMulti) {
+			printer.Warnf("%s does not support TXT records with multiple strings. Skipping %s\n", targetType, rc.GetLabelFQDN())
+			continue
+		}
+		dc.Records = append(dc.Records, &rc)
+	}
+	return dc, nil
+}
@@ -0,0 +1,79 @@
+package providers_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/v3/providers"
+	_ "github.com/StackExchange/dnscontrol/v3/providers/gandi_v5"
+	_ "github.com/StackExchange/dnscontrol/v3/providers/hetzner"
+)
+
+// gandiTransport answers all requests with the records of a Gandi zone.
+type gandiTransport struct{}
+
+func (gandiTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body := `[
+		{"rrset_name":"@","rrset_type":"A","rrset_ttl":300,"rrset_values":["192.0.2.1"]},
+		{"rrset_name":"@","rrset_type":"MX","rrset_ttl":300,"rrset_values":["10 mail"]},
+		{"rrset_name":"www","rrset_type":"CNAME","rrset_ttl":300,"rrset_values":["@"]},
+		{"rrset_name":"_sip._tcp","rrset_type":"SRV","rrset_ttl":300,"rrset_values":["10 60 5060 sip.example.com."]},
+		{"rrset_name":"1","rrset_type":"PTR","rrset_ttl":300,"rrset_values":["host.example.com."]},
+		{"rrset_name":"host","rrset_type":"SSHFP","rrset_ttl":300,"rrset_values":["1 1 0123456789abcdef0123456789abcdef01234567"]}
+	]`
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       ioutil.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}, nil
+}
+
+func TestMigrate(t *testing.T) {
+	old := http.DefaultTransport
+	http.DefaultTransport = gandiTransport{}
+	defer func() { http.DefaultTransport = old }()
+
+	gandi, err := providers.CreateDNSProvider("GANDI_V5", map[string]string{"apikey": "test"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dc, err := providers.Migrate(gandi, "example.com", "HETZNER")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// HETZNER supports neither PTR nor SSHFP.
+	var got []string
+	for _, rc := range dc.Records {
+		got = append(got, rc.GetLabel()+" "+rc.Type+" "+rc.GetTargetCombined())
+		if rc.Original != nil {
+			t.Errorf("expected the Gandi record to be dropped from %s", rc.GetLabelFQDN())
+		}
+	}
+	sort.Strings(got)
+	want := []string{
+		"@ A 192.0.2.1",
+		"@ MX 10 mail.example.com.",
+		"_sip._tcp SRV 10 60 5060 sip.example.com.",
+		"www CNAME example.com.",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("expected records %q; got=%q", want, got)
+	}
+
+	hetzner, err := providers.CreateDNSProvider("HETZNER", map[string]string{"api_key": "test"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	corrections, err := hetzner.(providers.OfflineDiffer).GetDomainCorrectionsAgainst(dc, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(corrections) != 1 || strings.Count(corrections[0].Msg, "CREATE") != len(want) {
+		t.Errorf("expected a single batch creating %d records; got=%v", len(want), corrections)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/StackExchange/dnscontrol/v3/models"
 )
@@ -31,6 +32,48 @@ type ZoneLister interface {
 	ListZones() ([]string, error)
 }
 
+// ZonesRecordsGetter should be implemented by providers that can fetch
+// the records of many zones faster than one zone at a time. The records
+// are returned in the same order as the zones.
+type ZonesRecordsGetter interface {
+	GetZonesRecords(zones []string) ([]models.Records, error)
+}
+
+// OfflineDiffer should be implemented by providers that can generate
+// corrections against a supplied set of existing records rather than
+// the live zone. This facilitates testing and what-if analysis.
+type OfflineDiffer interface {
+	GetDomainCorrectionsAgainst(dc *models.DomainConfig, existing models.Records) ([]*models.Correction, error)
+}
+
+// ModifiedRecordsGetter should be implemented by providers that record
+// when each record was last changed. This facilitates monitoring a zone
+// for changes made outside of dnscontrol.
+type ModifiedRecordsGetter interface {
+	GetZoneRecordsModifiedSince(zone string, since time.Time) (models.Records, error)
+}
+
+// ZoneExporter should be implemented by providers whose GetZoneRecords
+// returns records in a form specific to the provider, e.g. with targets
+// relative to the zone. ExportZoneRecords returns them in a form any
+// other provider can use, which facilitates migrating zones.
+type ZoneExporter interface {
+	ExportZoneRecords(zone string) (models.Records, error)
+}
+
+// SelfTester should be implemented by providers that can check against
+// the live account that each record type they claim to support is
+// accepted. This facilitates the "self-test" command.
+type SelfTester interface {
+	SelfTest(zone string) ([]SelfTestResult, error)
+}
+
+// SelfTestResult is the outcome of creating a record of one type.
+type SelfTestResult struct {
+	Type string
+	Err  error // nil if the provider accepted the record.
+}
+
 // RegistrarInitializer is a function to create a registrar. Function will be passed the unprocessed json payload from the configuration file for the given provider.
 type RegistrarInitializer func(map[string]string) (Registrar, error)
 
@@ -0,0 +1,44 @@
+package providers
+
+import (
+	"sort"
+)
+
+// CredentialVerifier should be implemented by providers that can
+// cheaply check that the API accepts their credentials.
+type CredentialVerifier interface {
+	VerifyCredentials() error
+}
+
+// VerifyResult is the outcome of verifying the credentials of one provider.
+type VerifyResult struct {
+	Name    string
+	Err     error
+	Skipped bool // The provider offers no way to verify its credentials.
+}
+
+// VerifyAll checks the credentials of each provider and returns the
+// results sorted by name. Providers that don't implement
+// CredentialVerifier are checked by listing their zones, if they can.
+func VerifyAll(dsps map[string]DNSServiceProvider) []VerifyResult {
+	names := make([]string, 0, len(dsps))
+	for name := range dsps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	results := make([]VerifyResult, 0, len(names))
+	for _, name := range names {
+		result := VerifyResult{Name: name}
+		switch p := dsps[name].(type) {
+		case CredentialVerifier:
+			result.Err = p.VerifyCredentials()
+		case ZoneLister:
+			_, result.Err = p.ListZones()
+		default:
+			result.Skipped = true
+		}
+		results = append(results, result)
+	}
+	return results
+}
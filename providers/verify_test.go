@@ -0,0 +1,49 @@
+package providers
+
+import (
+	"fmt"
+	"testing"
+)
+
+type verifyingProvider struct {
+	None
+	err error
+}
+
+func (p verifyingProvider) VerifyCredentials() error { return p.err }
+
+type listingProvider struct {
+	None
+	err error
+}
+
+func (p listingProvider) ListZones() ([]string, error) { return nil, p.err }
+
+func TestVerifyAll(t *testing.T) {
+	results := VerifyAll(map[string]DNSServiceProvider{
+		"d-healthy":   verifyingProvider{},
+		"c-unhealthy": verifyingProvider{err: fmt.Errorf("401 unauthorized")},
+		"b-lister":    listingProvider{err: fmt.Errorf("403 forbidden")},
+		"a-none":      None{},
+	})
+
+	var tests = []struct {
+		name    string
+		failed  bool
+		skipped bool
+	}{
+		{"a-none", false, true},
+		{"b-lister", true, false},
+		{"c-unhealthy", true, false},
+		{"d-healthy", false, false},
+	}
+	if len(results) != len(tests) {
+		t.Fatalf("Expected %d results, got %d", len(tests), len(results))
+	}
+	for i, test := range tests {
+		r := results[i]
+		if r.Name != test.name || (r.Err != nil) != test.failed || r.Skipped != test.skipped {
+			t.Errorf("%d: Expected %+v, got %+v", i, test, r)
+		}
+	}
+}